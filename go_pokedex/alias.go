@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// maxExpansion bounds how deeply aliases and macros may expand into each
+// other, so that alias a=b with alias b=a cannot loop forever.
+const maxExpansion = 8
+
+// aliasFile is where user aliases and macros are kept, one per line:
+//
+//	alias ex=explore --version=red
+//	macro scout=travel $1; explore
+func aliasFile() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aliases"), nil
+}
+
+func loadAliases(configure *config) error {
+	path, err := aliasFile()
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kind, definition, _ := strings.Cut(line, " ")
+		name, expansion, ok := strings.Cut(definition, "=")
+		if !ok {
+			continue
+		}
+		switch kind {
+		case "alias":
+			configure.aliases[strings.TrimSpace(name)] = strings.TrimSpace(expansion)
+		case "macro":
+			configure.macros[strings.TrimSpace(name)] = strings.TrimSpace(expansion)
+		}
+	}
+	return scanner.Err()
+}
+
+func saveAliases(configure *config) error {
+	path, err := aliasFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, name := range sortedKeys(configure.aliases) {
+		fmt.Fprintf(&b, "alias %s=%s\n", name, configure.aliases[name])
+	}
+	for _, name := range sortedKeys(configure.macros) {
+		fmt.Fprintf(&b, "macro %s=%s\n", name, configure.macros[name])
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// expandMacro splits a macro into its commands and substitutes $1..$9 with
+// the positional arguments and $@ with all of them.
+func expandMacro(macro string, args []string) []string {
+	steps := make([]string, 0)
+	for _, step := range strings.Split(macro, ";") {
+		words := strings.Fields(step)
+		for i, word := range words {
+			if word == "$@" {
+				words[i] = strings.Join(args, " ")
+			} else if n, err := strconv.Atoi(strings.TrimPrefix(word, "$")); err == nil && strings.HasPrefix(word, "$") && n >= 1 {
+				words[i] = ""
+				if n <= len(args) {
+					words[i] = args[n-1]
+				}
+			}
+		}
+		if step = strings.Join(strings.Fields(strings.Join(words, " ")), " "); step != "" {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// defineShortcut checks and stores a user alias or macro definition of the
+// form name=expansion.
+func defineShortcut(configure *config, kind string, definition string, into map[string]string) error {
+	name, expansion, ok := strings.Cut(definition, "=")
+	name, expansion = strings.TrimSpace(name), strings.TrimSpace(expansion)
+	if !ok || name == "" || expansion == "" || strings.ContainsAny(name, " ;") {
+		return usageError(configure, kind)
+	}
+	if _, builtin := lookupCommand(configure.commands, name); builtin {
+		return fmt.Errorf("%s is already a command", name)
+	}
+	delete(configure.aliases, name)
+	delete(configure.macros, name)
+	into[name] = expansion
+	return saveAliases(configure)
+}
+
+func listShortcuts(empty string, shortcuts map[string]string) {
+	if len(shortcuts) == 0 {
+		fmt.Println(empty)
+		return
+	}
+	for _, name := range sortedKeys(shortcuts) {
+		fmt.Printf("%s=%s\n", name, shortcuts[name])
+	}
+}
+
+func commandAlias(configure *config, c *pokecache.Cache, AreaName string) error {
+	if len(AreaName) == 0 {
+		listShortcuts(configure.tr("no aliases defined"), configure.aliases)
+		return nil
+	}
+	return defineShortcut(configure, "alias", AreaName, configure.aliases)
+}
+
+func commandMacro(configure *config, c *pokecache.Cache, AreaName string) error {
+	if len(AreaName) == 0 {
+		listShortcuts(configure.tr("no macros defined"), configure.macros)
+		return nil
+	}
+	return defineShortcut(configure, "macro", AreaName, configure.macros)
+}
+
+func commandUnalias(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, inAliases := configure.aliases[AreaName]
+	_, inMacros := configure.macros[AreaName]
+	if !inAliases && !inMacros {
+		return fmt.Errorf("no alias or macro named %s", AreaName)
+	}
+	delete(configure.aliases, AreaName)
+	delete(configure.macros, AreaName)
+	return saveAliases(configure)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandMacro(t *testing.T) {
+	cases := []struct {
+		macro string
+		args  []string
+		want  []string
+	}{
+		{"explore $1; catch $2", []string{"pallet", "pikachu"}, []string{"explore pallet", "catch pikachu"}},
+		{"catch $@", []string{"pikachu", "raichu"}, []string{"catch pikachu raichu"}},
+		{"inspect $3", []string{"pikachu"}, []string{"inspect"}},
+		{"map; ; mapb", nil, []string{"map", "mapb"}},
+		{"echo $0 $x", []string{"a"}, []string{"echo $0 $x"}},
+		{"  travel   $1  ", []string{"pallet-town"}, []string{"travel pallet-town"}},
+	}
+	for _, tc := range cases {
+		if got := expandMacro(tc.macro, tc.args); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("expandMacro(%q, %v) = %q, want %q", tc.macro, tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestDefineShortcut(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	configure := &config{aliases: make(map[string]string), macros: make(map[string]string)}
+	configure.commands = get_commands(configure)
+	if err := defineShortcut(configure, "macro", "scout=travel $1; explore", configure.macros); err != nil {
+		t.Fatal(err)
+	}
+	if err := defineShortcut(configure, "alias", "ex = explore --version=red", configure.aliases); err != nil {
+		t.Fatal(err)
+	}
+	// redefining a name as the other kind replaces it
+	if err := defineShortcut(configure, "alias", "scout=map", configure.aliases); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []string{"map=mapb", "=explore", "ex=", "two words=map", "noequals"} {
+		if err := defineShortcut(configure, "alias", bad, configure.aliases); err == nil {
+			t.Errorf("alias %q was accepted", bad)
+		}
+	}
+
+	loaded := &config{aliases: make(map[string]string), macros: make(map[string]string)}
+	if err := loadAliases(loaded); err != nil {
+		t.Fatal(err)
+	}
+	wantAliases := map[string]string{"ex": "explore --version=red", "scout": "map"}
+	if !reflect.DeepEqual(loaded.aliases, wantAliases) || len(loaded.macros) != 0 {
+		t.Errorf("loaded aliases %v and macros %v, want %v and none", loaded.aliases, loaded.macros, wantAliases)
+	}
+}
+
+func TestRunLineStopsExpansionLoops(t *testing.T) {
+	configure := &config{
+		aliases: map[string]string{"ping": "pong"},
+		macros:  map[string]string{"pong": "ping; ping"},
+	}
+	out := captureStdout(t, func() { runLine(configure, nil, "ping", 0) })
+	if !strings.Contains(out, "expands too deeply") {
+		t.Errorf("looping aliases printed %q, want an expansion error", out)
+	}
+}
+
+func TestShortcutsKeepArgumentCase(t *testing.T) {
+	quiet(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c := tradeCache()
+	ash := trainer(t, c, "ash", "", "pikachu", "rattata")
+	ash.commands = get_commands(ash)
+	ash.aliases = map[string]string{"ex": "export-mon"}
+	ash.macros = map[string]string{"gift": "export-mon $1 $2 $3"}
+	dir := filepath.Join(t.TempDir(), "Gifts")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	misty := publicKeyString(testKey(t))
+	runLine(ash, c, "ex pikachu "+misty+" "+filepath.Join(dir, "Pikachu.json"), 0)
+	runLine(ash, c, "gift rattata "+misty+" "+filepath.Join(dir, "Rattata.json"), 0)
+	for _, name := range []string{"Pikachu.json", "Rattata.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("export through a shortcut did not write %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+const pokeAPI = "https://pokeapi.co/api/v2/"
+
+// errNotFound is returned by fetchJSON when the API answers with 404.
+var errNotFound = fmt.Errorf("not found")
+
+// fetchJSON decodes the resource at url into v, going through the cache.
+// Only successful responses are cached.
+func fetchJSON(c *pokecache.Cache, url string, v any) error {
+	body, exists := c.Get(url)
+	if !exists {
+		res, err := http.Get(url)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode == http.StatusNotFound {
+			return errNotFound
+		}
+		if res.StatusCode > 299 {
+			return fmt.Errorf("error on Get request: %v", res.StatusCode)
+		}
+		body, err = io.ReadAll(res.Body)
+		if err != nil {
+			return err
+		}
+		c.Add(url, body)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("error parsing json %v", err)
+	}
+	return nil
+}
+
+// fetchPokemon also keeps the Pokemon in the on-disk asset cache, so the
+// species looked up in earlier sessions can still be searched, see
+// cachedSpecies.
+func fetchPokemon(c *pokecache.Cache, name string) (Pokemon, error) {
+	poke := Pokemon{}
+	url := pokeAPI + "pokemon/" + name
+	if c.Assets != nil {
+		if body, _, exists := c.Assets.Get(url); exists && json.Unmarshal(body, &poke) == nil {
+			return poke, nil
+		}
+	}
+	if err := fetchJSON(c, url, &poke); err != nil {
+		return poke, err
+	}
+	if c.Assets != nil {
+		if body, exists := c.Get(url); exists {
+			if err := c.Assets.Add(url, "application/json", body); err != nil {
+				log.Println("caching pokemon:", err)
+			}
+		}
+	}
+	return poke, nil
+}
+
+func fetchLocationArea(c *pokecache.Cache, name string) (locale_area, error) {
+	location := locale_area{}
+	err := fetchJSON(c, pokeAPI+"location-area/"+name, &location)
+	return location, err
+}
+
+type moveData struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Accuracy *int   `json:"accuracy"`
+	Power    *int   `json:"power"`
+	PP       int    `json:"pp"`
+	Priority int    `json:"priority"`
+	Type     struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"type"`
+	DamageClass struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"damage_class"`
+}
+
+func fetchMove(c *pokecache.Cache, name string) (moveData, error) {
+	move := moveData{}
+	err := fetchJSON(c, pokeAPI+"move/"+name, &move)
+	return move, err
+}
+
+type typeRelations struct {
+	DoubleDamageFrom []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"double_damage_from"`
+	DoubleDamageTo []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"double_damage_to"`
+	HalfDamageFrom []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"half_damage_from"`
+	HalfDamageTo []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"half_damage_to"`
+	NoDamageFrom []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"no_damage_from"`
+	NoDamageTo []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"no_damage_to"`
+}
+
+type typeData struct {
+	ID              int           `json:"id"`
+	Name            string        `json:"name"`
+	DamageRelations typeRelations `json:"damage_relations"`
+	// PastDamageRelations lists the relations that applied up to and
+	// including an older generation.
+	PastDamageRelations []struct {
+		Generation struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"generation"`
+		DamageRelations typeRelations `json:"damage_relations"`
+	} `json:"past_damage_relations"`
+	Generation struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"generation"`
+}
+
+func fetchType(c *pokecache.Cache, name string) (typeData, error) {
+	t := typeData{}
+	err := fetchJSON(c, pokeAPI+"type/"+name, &t)
+	return t, err
+}
+
+// multiplierAgainst returns how effective an attack using these relations is
+// against a single defending type.
+func (r typeRelations) multiplierAgainst(defender string) float64 {
+	for _, t := range r.NoDamageTo {
+		if t.Name == defender {
+			return 0
+		}
+	}
+	for _, t := range r.HalfDamageTo {
+		if t.Name == defender {
+			return 0.5
+		}
+	}
+	for _, t := range r.DoubleDamageTo {
+		if t.Name == defender {
+			return 2
+		}
+	}
+	return 1
+}
+
+// typeEffectiveness multiplies the effectiveness of attackType against every
+// one of the defender's types, using the chart of generation gen (0 for the
+// current one).
+func typeEffectiveness(c *pokecache.Cache, attackType string, defenderTypes []string, gen int) (float64, error) {
+	attack, err := fetchType(c, attackType)
+	if err != nil {
+		return 1, err
+	}
+	relations := attack.relationsIn(gen)
+	multiplier := 1.0
+	for _, d := range defenderTypes {
+		multiplier *= relations.multiplierAgainst(d)
+	}
+	return multiplier, nil
+}
+
+func (p Pokemon) typeNames() []string {
+	names := make([]string, 0, len(p.Types))
+	for _, t := range p.Types {
+		names = append(names, t.Type.Name)
+	}
+	return names
+}
+
+type speciesData struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	BaseHappiness int    `json:"base_happiness"`
+	// GenderRate is the chance of being female in eighths, or -1 when
+	// the species is genderless.
+	GenderRate int `json:"gender_rate"`
+	GrowthRate struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"growth_rate"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+}
+
+func fetchSpecies(c *pokecache.Cache, url string) (speciesData, error) {
+	species := speciesData{}
+	err := fetchJSON(c, url, &species)
+	return species, err
+}
+
+// fetchAsset downloads a binary asset such as a sprite and returns it with
+// its content type. Assets go to the on-disk asset cache when there is one;
+// failing to store them there is logged but does not fail the download.
+func fetchAsset(c *pokecache.Cache, url string) ([]byte, string, error) {
+	if c.Assets != nil {
+		if body, contentType, exists := c.Assets.Get(url); exists {
+			return body, contentType, nil
+		}
+	} else if body, exists := c.Get(url); exists {
+		return body, http.DetectContentType(body), nil
+	}
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode > 299 {
+		return nil, "", fmt.Errorf("error on Get request: %v", res.StatusCode)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	if c.Assets != nil {
+		if err := c.Assets.Add(url, contentType, body); err != nil {
+			// the download worked, only the next request has to fetch it again
+			log.Println("caching asset:", err)
+		}
+	} else {
+		c.Add(url, body)
+	}
+	return body, contentType, nil
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// struggle is used when a Pokemon knows no damaging moves.
+var struggle = moveData{Name: "struggle", PP: 1}
+
+func init() {
+	power := 50
+	struggle.Power = &power
+}
+
+type battler struct {
+	mon   *OwnedPokemon
+	name  string // display name in the trainer's language
+	hp    int
+	maxHP int
+	moves []moveData
+	wild  bool
+}
+
+// label names a battler in the battle's narration.
+func (bt *battle) label(b *battler) string {
+	if b.wild {
+		return fmt.Sprintf(bt.configure.tr("wild %s"), b.name)
+	}
+	return b.name
+}
+
+func (bt *battle) status(b *battler) string {
+	const width = 20
+	filled := 0
+	if b.maxHP > 0 {
+		filled = b.hp * width / b.maxHP
+	}
+	if b.hp > 0 && filled == 0 {
+		filled = 1
+	}
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", width-filled)
+	return fmt.Sprintf("%-22s Lv%-3d HP [%s] %d/%d", bt.label(b), b.mon.Level, bar, b.hp, b.maxHP)
+}
+
+func newBattler(configure *config, c *pokecache.Cache, mon *OwnedPokemon, wild bool) (*battler, error) {
+	b := &battler{mon: mon, name: configure.pokemonName(c, mon.Name), maxHP: mon.stat("hp"), wild: wild}
+	b.hp = b.maxHP
+	known := mon.KnownMoves
+	if len(known) == 0 {
+		known = mon.defaultMoves(versionFilter{})
+	}
+	for _, name := range known {
+		move, err := fetchMove(c, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load move %s: %v", name, err)
+		}
+		if move.Power == nil || *move.Power == 0 {
+			continue // status moves have no effect in this simulator
+		}
+		b.moves = append(b.moves, move)
+	}
+	if len(b.moves) == 0 {
+		b.moves = []moveData{struggle}
+	}
+	return b, nil
+}
+
+type battle struct {
+	configure *config
+	c         *pokecache.Cache
+	rng       *rand.Rand
+	player    *battler
+	wild      *battler
+}
+
+// moveName is the move's display name in the trainer's language.
+func (bt *battle) moveName(move moveData) string {
+	if move.Name == struggle.Name {
+		return move.Name
+	}
+	return bt.configure.localize(bt.c, "move", move.Name)
+}
+
+// damage applies the standard damage formula:
+//
+//	((2*level/5 + 2) * power * A/D) / 50 + 2
+//
+// scaled by STAB, type effectiveness, critical hits and a 85-100% roll.
+func (bt *battle) damage(attacker, defender *battler, move moveData) (int, float64, bool, error) {
+	atkStat, defStat := "attack", "defense"
+	if move.DamageClass.Name == "special" {
+		atkStat, defStat = "special-attack", "special-defense"
+	}
+	effectiveness := 1.0
+	if move.Type.Name != "" {
+		var err error
+		effectiveness, err = typeEffectiveness(bt.c, move.Type.Name, defender.mon.typeNames(), 0)
+		if err != nil {
+			return 0, 1, false, err
+		}
+	}
+	if effectiveness == 0 {
+		return 0, 0, false, nil
+	}
+	base := float64((2*attacker.mon.Level/5+2)*(*move.Power)*attacker.mon.stat(atkStat)/defender.mon.stat(defStat))/50 + 2
+	for _, t := range attacker.mon.typeNames() {
+		if t == move.Type.Name {
+			base *= 1.5
+			break
+		}
+	}
+	critical := bt.rng.Intn(24) == 0
+	if critical {
+		base *= 1.5
+	}
+	base *= effectiveness
+	base *= float64(85+bt.rng.Intn(16)) / 100
+	dmg := int(base)
+	if dmg < 1 {
+		dmg = 1
+	}
+	return dmg, effectiveness, critical, nil
+}
+
+func (bt *battle) attack(attacker, defender *battler, move moveData) error {
+	tr := bt.configure.tr
+	fmt.Printf(tr("%s used %s!")+"\n", bt.label(attacker), bt.moveName(move))
+	if move.Accuracy != nil && bt.rng.Intn(100) >= *move.Accuracy {
+		fmt.Println(tr("but it missed!"))
+		return nil
+	}
+	dmg, effectiveness, critical, err := bt.damage(attacker, defender, move)
+	if err != nil {
+		return err
+	}
+	if effectiveness == 0 {
+		fmt.Printf(tr("it doesn't affect %s...")+"\n", bt.label(defender))
+		return nil
+	}
+	if critical {
+		fmt.Println(tr("a critical hit!"))
+	}
+	if effectiveness > 1 {
+		fmt.Println(tr("it's super effective!"))
+	} else if effectiveness < 1 {
+		fmt.Println(tr("it's not very effective..."))
+	}
+	defender.hp -= dmg
+	if defender.hp < 0 {
+		defender.hp = 0
+	}
+	fmt.Printf(tr("%s took %d damage")+"\n", bt.label(defender), dmg)
+	return nil
+}
+
+// playerFirst orders the turn by move priority, then speed, then a coin flip.
+func (bt *battle) playerFirst(playerMove, wildMove moveData) bool {
+	if playerMove.Priority != wildMove.Priority {
+		return playerMove.Priority > wildMove.Priority
+	}
+	ps, ws := bt.player.mon.stat("speed"), bt.wild.mon.stat("speed")
+	if ps != ws {
+		return ps > ws
+	}
+	return bt.rng.Intn(2) == 0
+}
+
+// chooseMove asks the player for a move; it returns ok=false when the player
+// wants to run, or when there is no more input to read. In auto mode the
+// strongest move is picked without asking.
+func (bt *battle) chooseMove(configure *config, auto bool) (moveData, bool) {
+	if auto {
+		best := bt.player.moves[0]
+		for _, m := range bt.player.moves[1:] {
+			if *m.Power > *best.Power {
+				best = m
+			}
+		}
+		return best, true
+	}
+	for {
+		for i, m := range bt.player.moves {
+			fmt.Printf("  %d) %-16s %-9s "+configure.tr("power %d")+"\n", i+1, bt.moveName(m), configure.localize(bt.c, "type", m.Type.Name), *m.Power)
+		}
+		fmt.Println("  r) " + configure.tr("run"))
+		choice := prompt(configure, configure.tr("move > "))
+		if choice == "r" || choice == "run" || choice == "" {
+			return moveData{}, false
+		}
+		if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(bt.player.moves) {
+			return bt.player.moves[n-1], true
+		}
+		for _, m := range bt.player.moves {
+			if m.Name == choice || strings.ToLower(bt.moveName(m)) == choice {
+				return m, true
+			}
+		}
+		fmt.Println(configure.tr("pick a move number or r to run"))
+	}
+}
+
+// run resolves the battle and reports whether the player won.
+func (bt *battle) run(configure *config, auto bool) (bool, error) {
+	tr := configure.tr
+	fmt.Printf(tr("a wild %s (Lv%d) appeared!")+"\n", bt.wild.name, bt.wild.mon.Level)
+	fmt.Printf(tr("go, %s!")+"\n", bt.player.name)
+	for turn := 1; ; turn++ {
+		fmt.Printf("\n-- "+tr("turn %d")+" --\n", turn)
+		fmt.Println(bt.status(bt.wild))
+		fmt.Println(bt.status(bt.player))
+		playerMove, fight := bt.chooseMove(configure, auto)
+		if !fight {
+			if bt.player.mon.stat("speed") >= bt.wild.mon.stat("speed") || bt.rng.Intn(2) == 0 {
+				fmt.Println(tr("got away safely!"))
+				return false, nil
+			}
+			fmt.Println(tr("couldn't escape!"))
+		}
+		wildMove := bt.wild.moves[bt.rng.Intn(len(bt.wild.moves))]
+
+		order := []*battler{bt.player, bt.wild}
+		moves := []moveData{playerMove, wildMove}
+		if !fight {
+			order, moves = order[1:], moves[1:]
+		} else if !bt.playerFirst(playerMove, wildMove) {
+			order = []*battler{bt.wild, bt.player}
+			moves = []moveData{wildMove, playerMove}
+		}
+		for i, attacker := range order {
+			defender := bt.wild
+			if attacker == bt.wild {
+				defender = bt.player
+			}
+			if err := bt.attack(attacker, defender, moves[i]); err != nil {
+				return false, err
+			}
+			if defender.hp == 0 {
+				fmt.Printf(tr("%s fainted!")+"\n", bt.label(defender))
+				return defender == bt.wild, nil
+			}
+		}
+	}
+}
+
+// wildEncounter picks the opponent: a named species, or a random encounter
+// from the current area that appears in the filtered game version.
+func wildEncounter(configure *config, c *pokecache.Cache, rng *rand.Rand, lead *OwnedPokemon, name string, filter versionFilter) (*OwnedPokemon, error) {
+	level := lead.Level - 2 + rng.Intn(5)
+	if name == "" {
+		if configure.area == "" {
+			return nil, fmt.Errorf("travel to an area first or name a pokemon to battle")
+		}
+		location, err := fetchLocationArea(c, configure.area)
+		if err != nil {
+			return nil, err
+		}
+		type candidate struct {
+			name     string
+			min, max int
+		}
+		candidates := make([]candidate, 0)
+		for _, encounter := range location.PokemonEncounters {
+			for _, v := range encounter.VersionDetails {
+				if !filter.matchVersion(v.Version.Name) || len(v.EncounterDetails) == 0 {
+					continue
+				}
+				d := v.EncounterDetails[0]
+				candidates = append(candidates, candidate{encounter.Pokemon.Name, d.MinLevel, d.MaxLevel})
+				break
+			}
+		}
+		if len(candidates) == 0 {
+			return nil, fmt.Errorf("no pokemon in %s", configure.area)
+		}
+		picked := candidates[rng.Intn(len(candidates))]
+		name = picked.name
+		level = picked.min + rng.Intn(picked.max-picked.min+1)
+	}
+	if level < 1 {
+		level = 1
+	}
+	poke, err := fetchPokemon(c, name)
+	if err == errNotFound {
+		return nil, notFoundError(configure, c, "pokemon", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to find %s: %v", name, err)
+	}
+	return newOwnedPokemon(rng, poke, level, filter), nil
+}
+
+func commandBattle(configure *config, c *pokecache.Cache, AreaName string) error {
+	flags, args := parseArgs(AreaName)
+	if len(configure.party) == 0 {
+		return fmt.Errorf("your party is empty, catch a pokemon first")
+	}
+	lead := configure.caughtPokemon[configure.party[0]]
+
+	rng := configure.rng
+	if seed, ok := flags["seed"]; ok {
+		n, err := strconv.ParseInt(seed, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid seed %q", seed)
+		}
+		rng = rand.New(rand.NewSource(n))
+	}
+	_, auto := flags["auto"]
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	filter, err := gameFilter(configure, c, flags)
+	if err != nil {
+		return err
+	}
+	wildMon, err := wildEncounter(configure, c, rng, lead, name, filter)
+	if err != nil {
+		return err
+	}
+	player, err := newBattler(configure, c, lead, false)
+	if err != nil {
+		return err
+	}
+	wild, err := newBattler(configure, c, wildMon, true)
+	if err != nil {
+		return err
+	}
+	bt := &battle{configure: configure, c: c, rng: rng, player: player, wild: wild}
+	won, err := bt.run(configure, auto)
+	if err != nil {
+		return err
+	}
+	if won {
+		lead.gainEffort(wildMon.Pokemon)
+		fmt.Printf(configure.tr("%s won the battle!")+"\n", player.name)
+		lead.gainFriendship(1)
+		for _, held := range wildMon.HeldItems {
+			for _, v := range held.VersionDetails {
+				if !filter.matchVersion(v.Version.Name) {
+					continue
+				}
+				if rng.Intn(100) < v.Rarity {
+					configure.inventory[held.Item.Name]++
+					fmt.Printf(configure.tr("the wild %s dropped a %s")+"\n", wild.name, configure.localize(c, "item", held.Item.Name))
+				}
+				break
+			}
+		}
+		return gainExperience(configure, c, lead, expYield(wildMon), os.Stdout)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"io"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// battleCache holds the type chart entries the battle tests attack with, so
+// no request reaches the API.
+func battleCache() *pokecache.Cache {
+	c := pokecache.NewCache(time.Hour)
+	c.Add(pokeAPI+"type/normal", []byte(`{"name":"normal","damage_relations":{"half_damage_to":[{"name":"rock"}],"no_damage_to":[{"name":"ghost"}]}}`))
+	c.Add(pokeAPI+"type/water", []byte(`{"name":"water","damage_relations":{"double_damage_to":[{"name":"fire"}],"half_damage_to":[{"name":"water"},{"name":"grass"}]}}`))
+	return c
+}
+
+// quiet discards what the code under test prints until the test ends.
+func quiet(t *testing.T) {
+	t.Helper()
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+}
+
+func testMove(name, typ, class string, power, priority int) moveData {
+	m := moveData{Name: name, Power: &power, Priority: priority}
+	m.Type.Name = typ
+	m.DamageClass.Name = class
+	return m
+}
+
+func testBattler(t *testing.T, name string, types []string, base [6]int, level int, wild bool, moves ...moveData) *battler {
+	mon := &OwnedPokemon{
+		Pokemon: testPokemon(t, name, types, base),
+		Level:   level,
+		Nature:  "hardy",
+		IVs:     map[string]int{},
+		EVs:     map[string]int{},
+	}
+	return &battler{mon: mon, name: name, hp: mon.stat("hp"), maxHP: mon.stat("hp"), moves: moves, wild: wild}
+}
+
+func TestDamage(t *testing.T) {
+	c := battleCache()
+	tackle := testMove("tackle", "normal", "physical", 40, 0)
+	waterGun := testMove("water-gun", "water", "special", 40, 0)
+	squirtle := testBattler(t, "squirtle", []string{"water"}, [6]int{44, 48, 65, 50, 64, 43}, 50, false)
+	rattata := testBattler(t, "rattata", []string{"normal"}, [6]int{30, 56, 35, 25, 35, 72}, 50, true)
+	charmander := testBattler(t, "charmander", []string{"fire"}, [6]int{39, 52, 43, 60, 50, 65}, 50, true)
+	gastly := testBattler(t, "gastly", []string{"ghost", "poison"}, [6]int{30, 35, 30, 100, 35, 80}, 50, true)
+
+	// ((2*50/5+2) * 40 * 53/40) / 50 + 2, with no STAB for tackle
+	const neutral = 1166.0/50 + 2
+	cases := []struct {
+		name          string
+		attacker      *battler
+		defender      *battler
+		move          moveData
+		effectiveness float64
+		base          float64
+	}{
+		{"neutral", squirtle, rattata, tackle, 1, neutral},
+		{"immune", squirtle, gastly, tackle, 0, 0},
+		// ((22 * 40 * 55/55) / 50 + 2) * 1.5 STAB * 2 super effective
+		{"super effective with stab", squirtle, charmander, waterGun, 2, (880.0/50 + 2) * 1.5 * 2},
+	}
+	for _, tc := range cases {
+		for seed := int64(0); seed < 200; seed++ {
+			bt := &battle{configure: &config{}, c: c, rng: rand.New(rand.NewSource(seed))}
+			dmg, effectiveness, critical, err := bt.damage(tc.attacker, tc.defender, tc.move)
+			if err != nil {
+				t.Fatalf("%s: %v", tc.name, err)
+			}
+			if effectiveness != tc.effectiveness {
+				t.Fatalf("%s: effectiveness %v, want %v", tc.name, effectiveness, tc.effectiveness)
+			}
+			if tc.base == 0 {
+				if dmg != 0 {
+					t.Fatalf("%s: %d damage, want none", tc.name, dmg)
+				}
+				continue
+			}
+			base := tc.base
+			if critical {
+				base *= 1.5
+			}
+			low, high := int(base*0.85), int(base)
+			if dmg < low || dmg > high {
+				t.Fatalf("%s, seed %d: %d damage, want %d-%d (critical %v)", tc.name, seed, dmg, low, high, critical)
+			}
+		}
+	}
+}
+
+func TestPlayerFirst(t *testing.T) {
+	tackle := testMove("tackle", "normal", "physical", 40, 0)
+	quickAttack := testMove("quick-attack", "normal", "physical", 40, 1)
+	slow := testBattler(t, "slowpoke", []string{"water"}, [6]int{90, 65, 65, 40, 40, 15}, 30, false)
+	fast := testBattler(t, "jolteon", []string{"electric"}, [6]int{65, 65, 60, 110, 95, 130}, 30, true)
+	bt := &battle{rng: rand.New(rand.NewSource(1)), player: slow, wild: fast}
+	if bt.playerFirst(tackle, tackle) {
+		t.Error("the slower player moved first")
+	}
+	if !bt.playerFirst(quickAttack, tackle) {
+		t.Error("a higher priority move did not go first")
+	}
+	bt.player, bt.wild = fast, slow
+	if !bt.playerFirst(tackle, tackle) {
+		t.Error("the faster player did not move first")
+	}
+	if bt.playerFirst(tackle, quickAttack) {
+		t.Error("speed beat a higher priority move")
+	}
+
+	// equal speed is a coin flip
+	bt.wild = testBattler(t, "jolteon", []string{"electric"}, [6]int{65, 65, 60, 110, 95, 130}, 30, true)
+	first := map[bool]int{}
+	for i := 0; i < 100; i++ {
+		first[bt.playerFirst(tackle, tackle)]++
+	}
+	if first[true] == 0 || first[false] == 0 {
+		t.Errorf("speed ties always went the same way: %v", first)
+	}
+}
+
+func TestSeededBattleIsDeterministic(t *testing.T) {
+	quiet(t)
+	c := battleCache()
+	fight := func(seed int64) (bool, int, int) {
+		player := testBattler(t, "squirtle", []string{"water"}, [6]int{44, 48, 65, 50, 64, 43}, 12, false,
+			testMove("tackle", "normal", "physical", 40, 0), testMove("water-gun", "water", "special", 40, 0))
+		wild := testBattler(t, "rattata", []string{"normal"}, [6]int{30, 56, 35, 25, 35, 72}, 12, true,
+			testMove("tackle", "normal", "physical", 40, 0), testMove("quick-attack", "normal", "physical", 40, 1))
+		bt := &battle{configure: &config{}, c: c, rng: rand.New(rand.NewSource(seed)), player: player, wild: wild}
+		won, err := bt.run(bt.configure, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return won, player.hp, wild.hp
+	}
+	for seed := int64(1); seed <= 20; seed++ {
+		won, playerHP, wildHP := fight(seed)
+		againWon, againPlayerHP, againWildHP := fight(seed)
+		if won != againWon || playerHP != againPlayerHP || wildHP != againWildHP {
+			t.Errorf("seed %d: got won=%v hp %d/%d, then won=%v hp %d/%d",
+				seed, won, playerHP, wildHP, againWon, againPlayerHP, againWildHP)
+		}
+		if (playerHP == 0) == (wildHP == 0) {
+			t.Errorf("seed %d: battle ended with hp %d/%d", seed, playerHP, wildHP)
+		}
+	}
+}
+
+func TestSeededCatchIsRepeatable(t *testing.T) {
+	c := tradeCache()
+	poke, err := fetchPokemon(c, "pikachu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	throw := func(seed int64) catchResult {
+		configure := trainer(t, c, "", "")
+		configure.rng = rand.New(rand.NewSource(seed))
+		result, err := throwPokeball(configure, c, poke, io.Discard)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	caught := 0
+	for seed := int64(1); seed <= 20; seed++ {
+		first, again := throw(seed), throw(seed)
+		if first.Caught != again.Caught {
+			t.Errorf("seed %d: caught %v, then %v", seed, first.Caught, again.Caught)
+			continue
+		}
+		if !first.Caught {
+			continue
+		}
+		caught++
+		if a, b := first.Owned, again.Owned; a.Level != b.Level || a.Nature != b.Nature || a.Shiny != b.Shiny || a.Gender != b.Gender {
+			t.Errorf("seed %d: caught a level %d %s, then a level %d %s", seed, a.Level, a.Nature, b.Level, b.Nature)
+		}
+	}
+	if caught == 0 || caught == 20 {
+		t.Errorf("%d of 20 seeds caught pikachu, want some of both", caught)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// comparedPokemon is one column of the compare table, also used as the JSON
+// output.
+type comparedPokemon struct {
+	Name      string         `json:"name"`
+	Caught    bool           `json:"caught"`
+	Height    int            `json:"height"`
+	Weight    int            `json:"weight"`
+	Stats     map[string]int `json:"stats"`
+	Total     int            `json:"total"`
+	Types     []string       `json:"types"`
+	Abilities []string       `json:"abilities"`
+}
+
+func newComparedPokemon(p Pokemon, caught bool) comparedPokemon {
+	cp := comparedPokemon{
+		Name:   p.Name,
+		Caught: caught,
+		Height: p.Height,
+		Weight: p.Weight,
+		Stats:  make(map[string]int),
+		Types:  p.typeNames(),
+	}
+	for _, s := range p.Stats {
+		cp.Stats[s.Stat.Name] = s.BaseStat
+		cp.Total += s.BaseStat
+	}
+	for _, a := range p.Abilities {
+		ability := a.Ability.Name
+		if a.IsHidden {
+			ability += " (hidden)"
+		}
+		cp.Abilities = append(cp.Abilities, ability)
+	}
+	return cp
+}
+
+// numericRow builds a table row and marks the highest values.
+func numericRow(label string, values []int) ([]string, []bool) {
+	row := []string{label}
+	best := []bool{false}
+	max := values[0]
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	for _, v := range values {
+		row = append(row, strconv.Itoa(v))
+		best = append(best, v == max)
+	}
+	return row, best
+}
+
+func commandCompare(configure *config, c *pokecache.Cache, AreaName string) error {
+	flags, args := parseArgs(AreaName)
+	if len(args) < 2 {
+		return usageError(configure, "compare")
+	}
+	format := "table"
+	if f, ok := flags["format"]; ok {
+		format = f
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+
+	compared := make([]comparedPokemon, 0, len(args))
+	for _, name := range args {
+		if owned, exists := configure.caughtPokemon[name]; exists {
+			compared = append(compared, newComparedPokemon(owned.Pokemon, true))
+			continue
+		}
+		poke, err := fetchPokemon(c, name)
+		if err == errNotFound {
+			return notFoundError(configure, c, "pokemon", name)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to get %s: %v", name, err)
+		}
+		compared = append(compared, newComparedPokemon(poke, false))
+	}
+	if format == "json" {
+		return writeJSON(os.Stdout, compared)
+	}
+	header, rows, best := compareTable(configure, c, compared)
+	return writeTable(os.Stdout, format, header, rows, best)
+}
+
+// compareTable lays the compared Pokemon out side by side, one column each,
+// marking the highest numbers in every row.
+func compareTable(configure *config, c *pokecache.Cache, compared []comparedPokemon) ([]string, [][]string, [][]bool) {
+	header := []string{""}
+	for _, cp := range compared {
+		name := configure.pokemonName(c, cp.Name)
+		if cp.Caught {
+			name += " " + configure.tr("(caught)")
+		}
+		header = append(header, name)
+	}
+	rows := make([][]string, 0)
+	best := make([][]bool, 0)
+	addNumeric := func(label string, value func(cp comparedPokemon) int) {
+		values := make([]int, 0, len(compared))
+		for _, cp := range compared {
+			values = append(values, value(cp))
+		}
+		row, marks := numericRow(label, values)
+		rows = append(rows, row)
+		best = append(best, marks)
+	}
+	addText := func(label string, value func(cp comparedPokemon) []string) {
+		row := []string{label}
+		for _, cp := range compared {
+			row = append(row, strings.Join(value(cp), "/"))
+		}
+		rows = append(rows, row)
+		best = append(best, nil)
+	}
+
+	addNumeric(configure.tr("height"), func(cp comparedPokemon) int { return cp.Height })
+	addNumeric(configure.tr("weight"), func(cp comparedPokemon) int { return cp.Weight })
+	for _, stat := range statNames {
+		stat := stat
+		addNumeric(configure.localize(c, "stat", stat), func(cp comparedPokemon) int { return cp.Stats[stat] })
+	}
+	addNumeric(configure.tr("total"), func(cp comparedPokemon) int { return cp.Total })
+	addText(configure.tr("types"), func(cp comparedPokemon) []string {
+		types := make([]string, 0, len(cp.Types))
+		for _, t := range cp.Types {
+			types = append(types, configure.localize(c, "type", t))
+		}
+		return types
+	})
+	addText(configure.tr("abilities"), func(cp comparedPokemon) []string {
+		abilities := make([]string, 0, len(cp.Abilities))
+		for _, a := range cp.Abilities {
+			// hidden abilities carry a marker after the slug
+			if slug, hidden := strings.CutSuffix(a, " (hidden)"); hidden {
+				abilities = append(abilities, configure.localize(c, "ability", slug)+" "+configure.tr("(hidden)"))
+			} else {
+				abilities = append(abilities, configure.localize(c, "ability", a))
+			}
+		}
+		return abilities
+	})
+	return header, rows, best
+}
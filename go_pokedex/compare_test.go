@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+func TestCompareTable(t *testing.T) {
+	compared := []comparedPokemon{
+		{
+			Name: "charmander", Caught: true, Height: 6, Weight: 85, Total: 309,
+			Stats:     map[string]int{"hp": 39, "attack": 52, "defense": 43, "special-attack": 60, "special-defense": 50, "speed": 65},
+			Types:     []string{"fire"},
+			Abilities: []string{"blaze", "solar-power (hidden)"},
+		},
+		{
+			Name: "squirtle", Height: 5, Weight: 90, Total: 314,
+			Stats:     map[string]int{"hp": 44, "attack": 48, "defense": 65, "special-attack": 50, "special-defense": 64, "speed": 43},
+			Types:     []string{"water"},
+			Abilities: []string{"torrent"},
+		},
+	}
+
+	header, rows, best := compareTable(&config{}, nil, compared)
+	if want := []string{"", "charmander (caught)", "squirtle"}; !reflect.DeepEqual(header, want) {
+		t.Errorf("header = %q, want %q", header, want)
+	}
+	wantRows := [][]string{
+		{"height", "6", "5"},
+		{"weight", "85", "90"},
+		{"hp", "39", "44"},
+		{"attack", "52", "48"},
+		{"defense", "43", "65"},
+		{"special-attack", "60", "50"},
+		{"special-defense", "50", "64"},
+		{"speed", "65", "43"},
+		{"total", "309", "314"},
+		{"types", "fire", "water"},
+		{"abilities", "blaze/solar-power (hidden)", "torrent"},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("rows = %q, want %q", rows, wantRows)
+	}
+	// the higher value of every numeric row is marked, text rows are not
+	wantBest := [][]bool{
+		{false, true, false},
+		{false, false, true},
+		{false, false, true},
+		{false, true, false},
+		{false, false, true},
+		{false, true, false},
+		{false, false, true},
+		{false, true, false},
+		{false, false, true},
+		nil,
+		nil,
+	}
+	if !reflect.DeepEqual(best, wantBest) {
+		t.Errorf("best = %v, want %v", best, wantBest)
+	}
+
+	// ties mark every Pokemon sharing the highest value
+	if _, marks := numericRow("speed", []int{90, 90, 40}); !reflect.DeepEqual(marks, []bool{false, true, true, false}) {
+		t.Errorf("tied row marks = %v", marks)
+	}
+}
+
+func TestCompareTableLocalized(t *testing.T) {
+	c := pokecache.NewCache(time.Hour)
+	german := func(kind, slug, name string) {
+		c.Add(pokeAPI+kind+"/"+slug, []byte(fmt.Sprintf(`{"names":[{"name":%q,"language":{"name":"de"}}]}`, name)))
+	}
+	german("pokemon-species", "charmander", "Glumanda")
+	german("type", "fire", "Feuer")
+	german("ability", "blaze", "Großbrand")
+	german("ability", "solar-power", "Solarkraft")
+	german("stat", "speed", "Initiative")
+	configure := &config{language: "de", localNames: make(map[string]string)}
+	compared := []comparedPokemon{{
+		Name: "charmander", Caught: true,
+		Stats:     map[string]int{"speed": 65},
+		Types:     []string{"fire"},
+		Abilities: []string{"blaze", "solar-power (hidden)"},
+	}}
+
+	header, rows, _ := compareTable(configure, c, compared)
+	if want := []string{"", "Glumanda (gefangen)"}; !reflect.DeepEqual(header, want) {
+		t.Errorf("header = %q, want %q", header, want)
+	}
+	labels := make([]string, 0, len(rows))
+	for _, row := range rows {
+		labels = append(labels, row[0])
+	}
+	// stats without a German name in the cache keep their slug
+	wantLabels := []string{"Größe", "Gewicht", "hp", "attack", "defense", "special-attack", "special-defense", "Initiative", "Summe", "Typen", "Fähigkeiten"}
+	if !reflect.DeepEqual(labels, wantLabels) {
+		t.Errorf("row labels = %q, want %q", labels, wantLabels)
+	}
+	if got := rows[len(rows)-2][1]; got != "Feuer" {
+		t.Errorf("types = %q, want Feuer", got)
+	}
+	if got := rows[len(rows)-1][1]; got != "Großbrand/Solarkraft (versteckt)" {
+		t.Errorf("abilities = %q, want Großbrand/Solarkraft (versteckt)", got)
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+const maxFriendship = 255
+
+type evolutionDetail struct {
+	Trigger struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"trigger"`
+	Item *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"item"`
+	HeldItem *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"held_item"`
+	KnownMove *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"known_move"`
+	Location *struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location"`
+	Gender       *int   `json:"gender"`
+	MinLevel     *int   `json:"min_level"`
+	MinHappiness *int   `json:"min_happiness"`
+	TimeOfDay    string `json:"time_of_day"`
+}
+
+type chainLink struct {
+	Species struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"species"`
+	EvolutionDetails []evolutionDetail `json:"evolution_details"`
+	EvolvesTo        []chainLink       `json:"evolves_to"`
+}
+
+type evolutionChain struct {
+	ID    int       `json:"id"`
+	Chain chainLink `json:"chain"`
+}
+
+func fetchEvolutionChain(c *pokecache.Cache, speciesURL string) (evolutionChain, error) {
+	chain := evolutionChain{}
+	species, err := fetchSpecies(c, speciesURL)
+	if err != nil {
+		return chain, err
+	}
+	err = fetchJSON(c, species.EvolutionChain.URL, &chain)
+	return chain, err
+}
+
+// find returns the link for a species anywhere below l.
+func (l *chainLink) find(species string) *chainLink {
+	if l.Species.Name == species {
+		return l
+	}
+	for i := range l.EvolvesTo {
+		if found := l.EvolvesTo[i].find(species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func (d evolutionDetail) String() string {
+	parts := make([]string, 0)
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *d.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if d.Item != nil {
+			parts = append(parts, "use "+d.Item.Name)
+		}
+	default:
+		parts = append(parts, d.Trigger.Name)
+	}
+	if d.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("friendship %d", *d.MinHappiness))
+	}
+	if d.HeldItem != nil {
+		parts = append(parts, "holding "+d.HeldItem.Name)
+	}
+	if d.KnownMove != nil {
+		parts = append(parts, "knowing "+d.KnownMove.Name)
+	}
+	if d.Location != nil {
+		parts = append(parts, "at "+d.Location.Name)
+	}
+	if d.TimeOfDay != "" {
+		parts = append(parts, "during the "+d.TimeOfDay)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// met reports whether the owned Pokemon satisfies this evolution detail when
+// trigger fires with the given item. Conditions this tool does not track
+// (held items, locations) are never met.
+func (d evolutionDetail) met(o *OwnedPokemon, trigger, item string) bool {
+	if d.Trigger.Name != trigger {
+		return false
+	}
+	if d.HeldItem != nil || d.Location != nil {
+		return false
+	}
+	// PokeAPI genders: 1 is female, 2 is male.
+	if d.Gender != nil && (*d.Gender == 1) != (o.Gender == "female") {
+		return false
+	}
+	if d.Item != nil && d.Item.Name != item {
+		return false
+	}
+	if d.MinLevel != nil && o.Level < *d.MinLevel {
+		return false
+	}
+	if d.MinHappiness != nil && o.Friendship < *d.MinHappiness {
+		return false
+	}
+	if d.KnownMove != nil && !o.knowsMove(d.KnownMove.Name) {
+		return false
+	}
+	if d.TimeOfDay != "" {
+		hour := time.Now().Hour()
+		day := hour >= 6 && hour < 18
+		if (d.TimeOfDay == "day") != day {
+			return false
+		}
+	}
+	return true
+}
+
+func (o *OwnedPokemon) knowsMove(move string) bool {
+	for _, known := range o.KnownMoves {
+		if known == move {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *OwnedPokemon) gainFriendship(n int) {
+	o.Friendship += n
+	if o.Friendship > maxFriendship {
+		o.Friendship = maxFriendship
+	}
+}
+
+// tryEvolve evolves o if one of its evolutions is triggered by trigger
+// ("level-up", "use-item", "trade") with the given item. The evolved Pokemon
+// keeps its individual values and replaces the old entry in the Pokedex;
+// what happens is written to w.
+func tryEvolve(configure *config, c *pokecache.Cache, o *OwnedPokemon, trigger, item string, w io.Writer) (bool, error) {
+	chain, err := fetchEvolutionChain(c, o.Species.URL)
+	if err != nil {
+		return false, err
+	}
+	link := chain.Chain.find(o.Species.Name)
+	if link == nil {
+		return false, nil
+	}
+	for _, next := range link.EvolvesTo {
+		for _, d := range next.EvolutionDetails {
+			if !d.met(o, trigger, item) {
+				continue
+			}
+			if _, exists := configure.caughtPokemon[next.Species.Name]; exists {
+				fmt.Fprintf(w, configure.tr("%s could evolve into %s, but you already have one")+"\n",
+					configure.pokemonName(c, o.Name), configure.pokemonName(c, next.Species.Name))
+				return false, nil
+			}
+			poke, err := fetchPokemon(c, next.Species.Name)
+			if err != nil {
+				return false, err
+			}
+			evolve(configure, c, o, poke, w)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func evolve(configure *config, c *pokecache.Cache, o *OwnedPokemon, poke Pokemon, w io.Writer) {
+	old := o.Name
+	fmt.Fprintf(w, configure.tr("what? %s is evolving!")+"\n", configure.pokemonName(c, old))
+	o.Pokemon = poke
+	delete(configure.caughtPokemon, old)
+	configure.caughtPokemon[o.Name] = o
+	// the old species stays in the pokedex, it was caught after all
+	if configure.registered == nil {
+		configure.registered = make(map[string]bool)
+	}
+	configure.registered[old] = true
+	delete(configure.registered, o.Name)
+	for i, name := range configure.party {
+		if name == old {
+			configure.party[i] = o.Name
+		}
+	}
+	fmt.Fprintf(w, configure.tr("%s evolved into %s!")+"\n", configure.pokemonName(c, old), configure.pokemonName(c, o.Name))
+	fmt.Fprintf(w, configure.tr("%s was registered in the pokedex")+"\n", configure.pokemonName(c, o.Name))
+}
+
+func printChain(configure *config, c *pokecache.Cache, l chainLink, indent string, last bool, root bool) {
+	line := configure.pokemonName(c, l.Species.Name)
+	if _, exists := configure.caughtPokemon[l.Species.Name]; exists {
+		line += " *"
+	}
+	details := make([]string, 0, len(l.EvolutionDetails))
+	for _, d := range l.EvolutionDetails {
+		details = append(details, d.String())
+	}
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, " or ") + ")"
+	}
+	childIndent := indent
+	if root {
+		fmt.Println(line)
+	} else if last {
+		fmt.Println(indent + "└── " + line)
+		childIndent += "    "
+	} else {
+		fmt.Println(indent + "├── " + line)
+		childIndent += "│   "
+	}
+	for i, next := range l.EvolvesTo {
+		printChain(configure, c, next, childIndent, i == len(l.EvolvesTo)-1, false)
+	}
+}
+
+func commandEvolutions(configure *config, c *pokecache.Cache, AreaName string) error {
+	if len(AreaName) == 0 {
+		return fmt.Errorf("no pokemon")
+	}
+	speciesURL := pokeAPI + "pokemon-species/" + AreaName
+	if owned, exists := configure.caughtPokemon[AreaName]; exists {
+		speciesURL = owned.Species.URL
+	}
+	chain, err := fetchEvolutionChain(c, speciesURL)
+	if err == errNotFound {
+		return notFoundError(configure, c, "pokemon", AreaName)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get evolution chain for %s: %v", AreaName, err)
+	}
+	printChain(configure, c, chain.Chain, "", true, true)
+	fmt.Println("(* " + configure.tr("caught") + ")")
+	return nil
+}
+
+func commandUse(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) != 2 {
+		return usageError(configure, "use")
+	}
+	item, name := args[0], args[1]
+	if configure.inventory[item] == 0 {
+		bag := make([]string, 0, len(configure.inventory))
+		for held := range configure.inventory {
+			bag = append(bag, held)
+		}
+		if suggestions := closestNames(bag, item); len(suggestions) > 0 {
+			return fmt.Errorf("you have no %s%s", item, configure.didYouMean(suggestions))
+		}
+		if items, err := nameIndex(configure, c, "item"); err == nil {
+			if i := sort.SearchStrings(items, item); i == len(items) || items[i] != item {
+				return notFoundError(configure, c, "item", item)
+			}
+		}
+		return fmt.Errorf("you have no %s", item)
+	}
+	owned, exists := configure.caughtPokemon[name]
+	if !exists {
+		fmt.Println(configure.tr("you have not caught that pokemon") + notCaughtHint(configure, c, name))
+		return nil
+	}
+	evolved, err := tryEvolve(configure, c, owned, "use-item", item, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if !evolved {
+		fmt.Println(configure.tr("it had no effect"))
+		return nil
+	}
+	configure.inventory[item]--
+	if configure.inventory[item] == 0 {
+		delete(configure.inventory, item)
+	}
+	return nil
+}
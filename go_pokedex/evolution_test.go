@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// evolutionCache serves charmander, which evolves into charmeleon at level 16.
+func evolutionCache() *pokecache.Cache {
+	c := pokecache.NewCache(time.Hour)
+	for id, name := range map[int]string{4: "charmander", 5: "charmeleon"} {
+		c.Add(pokeAPI+"pokemon/"+name, []byte(fmt.Sprintf(
+			`{"id":%d,"name":%q,"species":{"name":%[2]q,"url":"%[3]spokemon-species/%[2]s/"},"types":[{"slot":1,"type":{"name":"fire"}}]}`,
+			id, name, pokeAPI)))
+		c.Add(pokeAPI+"pokemon-species/"+name+"/", []byte(fmt.Sprintf(
+			`{"name":%q,"growth_rate":{"name":"medium-slow"},"evolution_chain":{"url":"%sevolution-chain/2/"}}`, name, pokeAPI)))
+	}
+	c.Add(pokeAPI+"evolution-chain/2/", []byte(`{"id":2,"chain":{"species":{"name":"charmander"},"evolves_to":[`+
+		`{"species":{"name":"charmeleon"},"evolution_details":[{"trigger":{"name":"level-up"},"min_level":16}],"evolves_to":[]}]}}`))
+	return c
+}
+
+func TestTryEvolve(t *testing.T) {
+	c := evolutionCache()
+	poke, err := fetchPokemon(c, "charmander")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configure := &config{caughtPokemon: make(map[string]*OwnedPokemon)}
+	mon := &OwnedPokemon{Pokemon: poke, Level: 15}
+	configure.caughtPokemon["charmander"] = mon
+	configure.party = []string{"charmander"}
+
+	if evolved, err := tryEvolve(configure, c, mon, "level-up", "", io.Discard); err != nil || evolved {
+		t.Fatalf("level 15 charmander: evolved=%v, %v", evolved, err)
+	}
+	mon.Level = 16
+	if evolved, err := tryEvolve(configure, c, mon, "level-up", "", io.Discard); err != nil || !evolved {
+		t.Fatalf("level 16 charmander: evolved=%v, %v", evolved, err)
+	}
+	if configure.caughtPokemon["charmeleon"] != mon || mon.Name != "charmeleon" {
+		t.Errorf("box holds %v, want the same pokemon as charmeleon", configure.caughtPokemon)
+	}
+	if _, ok := configure.caughtPokemon["charmander"]; ok {
+		t.Error("charmander is still in the box after evolving")
+	}
+	if !configure.registered["charmander"] {
+		t.Error("charmander was dropped from the pokedex when it evolved")
+	}
+	if configure.party[0] != "charmeleon" {
+		t.Errorf("party = %v, want charmeleon in the lead", configure.party)
+	}
+}
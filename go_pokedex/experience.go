@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+const maxLevel = 100
+
+// expForLevel returns the total experience needed to reach level n for the
+// given PokeAPI growth rate.
+func expForLevel(rate string, n int) int {
+	if n <= 1 {
+		return 0
+	}
+	cube := n * n * n
+	switch rate {
+	case "slow":
+		return 5 * cube / 4
+	case "fast":
+		return 4 * cube / 5
+	case "medium-slow":
+		return 6*cube/5 - 15*n*n + 100*n - 140
+	case "slow-then-very-fast": // erratic
+		switch {
+		case n < 50:
+			return cube * (100 - n) / 50
+		case n < 68:
+			return cube * (150 - n) / 100
+		case n < 98:
+			return cube * ((1911 - 10*n) / 3) / 500
+		default:
+			return cube * (160 - n) / 100
+		}
+	case "fast-then-very-slow": // fluctuating
+		switch {
+		case n < 15:
+			return cube * ((n+1)/3 + 24) / 50
+		case n < 36:
+			return cube * (n + 14) / 50
+		default:
+			return cube * (n/2 + 32) / 50
+		}
+	default: // "medium", also known as medium-fast
+		return cube
+	}
+}
+
+// loadSpecies fills in what comes from the species: the growth rate, with
+// the experience counter at the minimum for the current level, the base
+// friendship and a gender rolled from the species gender rate.
+func (o *OwnedPokemon) loadSpecies(c *pokecache.Cache, rng *rand.Rand) error {
+	species, err := fetchSpecies(c, o.Species.URL)
+	if err != nil {
+		return err
+	}
+	o.GrowthRate = species.GrowthRate.Name
+	o.Exp = expForLevel(o.GrowthRate, o.Level)
+	o.Friendship = species.BaseHappiness
+	o.Gender = rollGender(rng, species.GenderRate)
+	return nil
+}
+
+// expYield is the experience earned for defeating or catching a Pokemon.
+func expYield(defeated *OwnedPokemon) int {
+	exp := defeated.BaseExperience * defeated.Level / 7
+	if exp < 1 {
+		exp = 1
+	}
+	return exp
+}
+
+// gainExperience adds exp and handles every level gained along the way,
+// including level-up evolutions, telling w about each step.
+func gainExperience(configure *config, c *pokecache.Cache, o *OwnedPokemon, exp int, w io.Writer) error {
+	if o.Level >= maxLevel {
+		return nil
+	}
+	o.Exp += exp
+	fmt.Fprintf(w, configure.tr("%s gained %d exp")+"\n", configure.pokemonName(c, o.Name), exp)
+	for o.Level < maxLevel && o.Exp >= expForLevel(o.GrowthRate, o.Level+1) {
+		o.Level++
+		fmt.Fprintf(w, configure.tr("%s grew to level %d!")+"\n", configure.pokemonName(c, o.Name), o.Level)
+		o.gainFriendship(5)
+		learnt := make([]string, 0)
+		for name, lvl := range o.levelUpMoves(configure.game) {
+			if lvl == o.Level {
+				learnt = append(learnt, name)
+			}
+		}
+		// map order is random, sort so seeded battles play out the same
+		sort.Strings(learnt)
+		for _, name := range learnt {
+			learnMove(configure, c, o, name, w)
+		}
+		if _, err := tryEvolve(configure, c, o, "level-up", "", w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// learnMove teaches a move, asking which one to forget when four are known.
+func learnMove(configure *config, c *pokecache.Cache, o *OwnedPokemon, move string, w io.Writer) {
+	for _, known := range o.KnownMoves {
+		if known == move {
+			return
+		}
+	}
+	name, moveName := configure.pokemonName(c, o.Name), configure.localize(c, "move", move)
+	if len(o.KnownMoves) < 4 {
+		o.KnownMoves = append(o.KnownMoves, move)
+		fmt.Fprintf(w, configure.tr("%s learned %s!")+"\n", name, moveName)
+		return
+	}
+	fmt.Fprintf(w, configure.tr("%s wants to learn %s, but already knows four moves")+"\n", name, moveName)
+	for {
+		for i, known := range o.KnownMoves {
+			fmt.Fprintf(w, "  %d) %s\n", i+1, configure.localize(c, "move", known))
+		}
+		choice := prompt(configure, configure.tr("forget which move? (1-4, or n to give up) > "))
+		if choice == "n" || choice == "" {
+			fmt.Fprintf(w, configure.tr("%s did not learn %s")+"\n", name, moveName)
+			return
+		}
+		if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(o.KnownMoves) {
+			fmt.Fprintf(w, configure.tr("%s forgot %s and learned %s!")+"\n", name, configure.localize(c, "move", o.KnownMoves[n-1]), moveName)
+			o.KnownMoves[n-1] = move
+			return
+		}
+	}
+}
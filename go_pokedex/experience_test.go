@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+func TestExpForLevel(t *testing.T) {
+	cases := []struct {
+		rate  string
+		level int
+		want  int
+	}{
+		{"medium", 1, 0},
+		{"medium", 100, 1000000},
+		{"slow", 100, 1250000},
+		{"fast", 100, 800000},
+		{"medium-slow", 2, 9},
+		{"medium-slow", 100, 1059860},
+		{"slow-then-very-fast", 50, 125000},
+		{"slow-then-very-fast", 100, 600000},
+		{"fast-then-very-slow", 15, 1957},
+		{"fast-then-very-slow", 100, 1640000},
+		{"", 10, 1000}, // unknown rates fall back to medium
+	}
+	for _, tc := range cases {
+		if got := expForLevel(tc.rate, tc.level); got != tc.want {
+			t.Errorf("expForLevel(%q, %d) = %d, want %d", tc.rate, tc.level, got, tc.want)
+		}
+	}
+}
+
+func TestExpForLevelIncreases(t *testing.T) {
+	for _, rate := range []string{"slow", "medium", "fast", "medium-slow", "slow-then-very-fast", "fast-then-very-slow"} {
+		for n := 2; n <= maxLevel; n++ {
+			if expForLevel(rate, n) <= expForLevel(rate, n-1) {
+				t.Errorf("%s: level %d needs %d exp, no more than level %d", rate, n, expForLevel(rate, n), n-1)
+			}
+		}
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	// a species that does not evolve, so levelling up stays offline
+	c := pokecache.NewCache(time.Hour)
+	c.Add(pokeAPI+"pokemon-species/tauros/", []byte(`{"name":"tauros","evolution_chain":{"url":"`+pokeAPI+`evolution-chain/58/"}}`))
+	c.Add(pokeAPI+"evolution-chain/58/", []byte(`{"id":58,"chain":{"species":{"name":"tauros"},"evolves_to":[]}}`))
+	configure := &config{caughtPokemon: make(map[string]*OwnedPokemon)}
+	mon := &OwnedPokemon{Level: 5, GrowthRate: "medium", Exp: expForLevel("medium", 5)}
+	mon.Name = "tauros"
+	mon.Species.Name = "tauros"
+	mon.Species.URL = pokeAPI + "pokemon-species/tauros/"
+	gainExperience(configure, c, mon, expForLevel("medium", 8)-mon.Exp+10, io.Discard)
+	if mon.Level != 8 || mon.Exp != expForLevel("medium", 8)+10 {
+		t.Errorf("after gaining exp: level %d with %d exp, want level 8 with %d", mon.Level, mon.Exp, expForLevel("medium", 8)+10)
+	}
+
+	gainExperience(configure, c, mon, 10000000, io.Discard)
+	if mon.Level != maxLevel {
+		t.Errorf("level = %d, want it capped at %d", mon.Level, maxLevel)
+	}
+	exp := mon.Exp
+	gainExperience(configure, c, mon, 100, io.Discard)
+	if mon.Exp != exp {
+		t.Errorf("a level %d pokemon gained exp", maxLevel)
+	}
+}
+
+func TestLearnMove(t *testing.T) {
+	configure := &config{input: bufio.NewScanner(strings.NewReader("9\n2\n"))}
+	mon := &OwnedPokemon{KnownMoves: []string{"tackle", "growl", "tail-whip"}}
+	learnMove(configure, nil, mon, "quick-attack", io.Discard)
+	learnMove(configure, nil, mon, "tackle", io.Discard)
+	learnMove(configure, nil, mon, "thunder-shock", io.Discard)
+	want := []string{"tackle", "thunder-shock", "tail-whip", "quick-attack"}
+	if !reflect.DeepEqual(mon.KnownMoves, want) {
+		t.Errorf("known moves = %v, want %v", mon.KnownMoves, want)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// encounterRow merges the encounter details of one Pokemon found with one
+// method under the same conditions, across versions.
+type encounterRow struct {
+	Pokemon    string         `json:"pokemon"`
+	Conditions []string       `json:"conditions"`
+	MinLevel   int            `json:"min_level"`
+	MaxLevel   int            `json:"max_level"`
+	Chances    map[string]int `json:"chances"` // version -> chance
+}
+
+func (r *encounterRow) chance() string {
+	lo, hi := -1, 0
+	for _, c := range r.Chances {
+		if lo < 0 || c < lo {
+			lo = c
+		}
+		hi = max(hi, c)
+	}
+	if lo == hi {
+		return strconv.Itoa(hi) + "%"
+	}
+	return fmt.Sprintf("%d-%d%%", lo, hi)
+}
+
+func (r *encounterRow) levels() string {
+	if r.MinLevel == r.MaxLevel {
+		return strconv.Itoa(r.MinLevel)
+	}
+	return fmt.Sprintf("%d-%d", r.MinLevel, r.MaxLevel)
+}
+
+// methodEncounters is every encounter of an area with one method, sorted by
+// Pokemon, with the encounter rate of the method in each version.
+type methodEncounters struct {
+	Method     string          `json:"method"`
+	Rates      map[string]int  `json:"rates"` // version -> rate
+	Encounters []*encounterRow `json:"encounters"`
+}
+
+// displayName is the name of the area in lang, falling back to English and
+// then to its slug.
+func (l locale_area) displayName(lang string) string {
+	return pickName(l.Names, lang, l.Name)
+}
+
+// groupEncounters merges the encounters of an area that appear in the
+// filtered versions, one entry per method sorted by method name.
+func groupEncounters(location locale_area, filter versionFilter) []methodEncounters {
+	rates := make(map[string]map[string]int)
+	for _, m := range location.EncounterMethodRates {
+		for _, v := range m.VersionDetails {
+			if !filter.matchVersion(v.Version.Name) {
+				continue
+			}
+			if rates[m.EncounterMethod.Name] == nil {
+				rates[m.EncounterMethod.Name] = make(map[string]int)
+			}
+			rates[m.EncounterMethod.Name][v.Version.Name] = v.Rate
+		}
+	}
+
+	methods := make(map[string]map[string]*encounterRow)
+	for _, e := range location.PokemonEncounters {
+		for _, v := range e.VersionDetails {
+			if !filter.matchVersion(v.Version.Name) {
+				continue
+			}
+			for _, d := range v.EncounterDetails {
+				conditions := make([]string, 0, len(d.ConditionValues))
+				for _, cv := range d.ConditionValues {
+					conditions = append(conditions, cv.Name)
+				}
+				sort.Strings(conditions)
+				key := e.Pokemon.Name + "|" + strings.Join(conditions, ",")
+				rows, ok := methods[d.Method.Name]
+				if !ok {
+					rows = make(map[string]*encounterRow)
+					methods[d.Method.Name] = rows
+				}
+				row, ok := rows[key]
+				if !ok {
+					row = &encounterRow{
+						Pokemon:    e.Pokemon.Name,
+						Conditions: conditions,
+						MinLevel:   d.MinLevel,
+						MaxLevel:   d.MaxLevel,
+						Chances:    make(map[string]int),
+					}
+					rows[key] = row
+				}
+				row.MinLevel = min(row.MinLevel, d.MinLevel)
+				row.MaxLevel = max(row.MaxLevel, d.MaxLevel)
+				row.Chances[v.Version.Name] += d.Chance
+			}
+		}
+	}
+
+	grouped := make([]methodEncounters, 0, len(methods))
+	for _, method := range sortedKeys(methods) {
+		rows := make([]*encounterRow, 0, len(methods[method]))
+		for _, row := range methods[method] {
+			rows = append(rows, row)
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			if rows[i].Pokemon != rows[j].Pokemon {
+				return rows[i].Pokemon < rows[j].Pokemon
+			}
+			return strings.Join(rows[i].Conditions, ",") < strings.Join(rows[j].Conditions, ",")
+		})
+		grouped = append(grouped, methodEncounters{Method: method, Rates: rates[method], Encounters: rows})
+	}
+	return grouped
+}
+
+// printEncounters shows one table per encounter method with the level range,
+// chance, conditions and versions of every Pokemon in the area.
+func printEncounters(configure *config, c *pokecache.Cache, location locale_area, filter versionFilter) error {
+	fmt.Printf("%s (%s)\n", location.displayName(configure.language), location.Name)
+	grouped := groupEncounters(location, filter)
+	if len(grouped) == 0 {
+		fmt.Println(configure.tr("no pokemon found"))
+		return nil
+	}
+	for _, m := range grouped {
+		fmt.Println()
+		method := configure.localize(c, "encounter-method", m.Method)
+		if len(m.Rates) > 0 {
+			rates := make([]string, 0, len(m.Rates))
+			for _, version := range sortedKeys(m.Rates) {
+				rates = append(rates, fmt.Sprintf("%s %d%%", version, m.Rates[version]))
+			}
+			fmt.Printf("%s ("+configure.tr("encounter rate: %s")+")\n", method, strings.Join(rates, ", "))
+		} else {
+			fmt.Println(method)
+		}
+		table := make([][]string, 0, len(m.Encounters))
+		for _, row := range m.Encounters {
+			localized := make([]string, 0, len(row.Conditions))
+			for _, cv := range row.Conditions {
+				localized = append(localized, configure.localize(c, "encounter-condition-value", cv))
+			}
+			conditions := strings.Join(localized, ", ")
+			if conditions == "" {
+				conditions = "-"
+			}
+			table = append(table, []string{configure.pokemonName(c, row.Pokemon), row.levels(), row.chance(), conditions, strings.Join(sortedKeys(row.Chances), ", ")})
+		}
+		if err := writeTable(os.Stdout, "table", []string{configure.tr("pokemon"), configure.tr("level"), configure.tr("chance"), configure.tr("conditions"), configure.tr("versions")}, table, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
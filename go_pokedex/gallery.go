@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// galleryDir is where sprites download writes, one folder per Pokemon.
+const galleryDir = "pokedex-gallery"
+
+// spriteVariants walks the Sprites struct and collects every sprite URL keyed
+// by its json path, e.g. "versions/generation-i/red-blue/front_default".
+func spriteVariants(v reflect.Value, prefix string, out map[string]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		field := v.Field(i)
+		if field.Kind() == reflect.Interface && !field.IsNil() {
+			field = field.Elem()
+		}
+		switch field.Kind() {
+		case reflect.String:
+			if field.String() != "" {
+				out[prefix+name] = field.String()
+			}
+		case reflect.Struct:
+			spriteVariants(field, prefix+name+"/", out)
+		}
+	}
+}
+
+func assetExtension(contentType, url string) string {
+	if exts, err := mime.ExtensionsByType(contentType); err == nil && len(exts) > 0 {
+		sort.Strings(exts)
+		for _, ext := range exts {
+			if ext == ".png" || ext == ".gif" || ext == ".svg" {
+				return ext
+			}
+		}
+		return exts[0]
+	}
+	return path.Ext(url)
+}
+
+func downloadSprites(configure *config, c *pokecache.Cache, o *OwnedPokemon) (int, error) {
+	variants := make(map[string]string)
+	spriteVariants(reflect.ValueOf(o.Sprites), "", variants)
+	saved := 0
+	for name, url := range variants {
+		data, contentType, err := fetchAsset(c, url)
+		if err != nil {
+			fmt.Printf(configure.tr("skipping %s: %v")+"\n", name, err)
+			continue
+		}
+		file := filepath.Join(galleryDir, o.Name, filepath.FromSlash(name)+assetExtension(contentType, url))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return saved, err
+		}
+		if err := os.WriteFile(file, data, 0o644); err != nil {
+			return saved, err
+		}
+		saved++
+	}
+	return saved, nil
+}
+
+func commandSprites(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) != 2 || args[0] != "download" {
+		return usageError(configure, "sprites")
+	}
+	owned, exists := configure.caughtPokemon[args[1]]
+	if !exists {
+		fmt.Println(configure.tr("you have not caught that pokemon") + notCaughtHint(configure, c, args[1]))
+		return nil
+	}
+	saved, err := downloadSprites(configure, c, owned)
+	if err != nil {
+		return err
+	}
+	fmt.Printf(configure.tr("saved %d sprites to %s")+"\n", saved, filepath.Join(galleryDir, owned.Name))
+	if c.Assets != nil {
+		fmt.Printf(configure.tr("the asset cache holds %.1f of %d MiB")+"\n", float64(c.Assets.Size())/(1<<20), assetBudget>>20)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// argSpec documents one argument or flag of a command.
+type argSpec struct {
+	name        string
+	description string
+}
+
+// commandGroups is the order the help listing shows its headings in.
+var commandGroups = []string{"world", "pokemon", "reference", "settings", "plugins", "general"}
+
+// lookupCommand finds a command by its name or one of its aliases.
+func lookupCommand(commands map[string]Commands, name string) (Commands, bool) {
+	if command, ok := commands[name]; ok {
+		return command, true
+	}
+	for _, command := range commands {
+		for _, alias := range command.aliases {
+			if alias == name {
+				return command, true
+			}
+		}
+	}
+	return Commands{}, false
+}
+
+// wantsHelp reports whether the arguments ask for the help page instead of
+// running the command.
+func wantsHelp(text string) bool {
+	flags, _ := parseArgs(text)
+	_, ok := flags["help"]
+	return ok
+}
+
+// writeCommandList writes every command under its group heading, sorted by
+// name within a group.
+func writeCommandList(w io.Writer, configure *config, commands map[string]Commands) {
+	grouped := make(map[string][]Commands)
+	width := 0
+	for _, command := range commands {
+		grouped[command.group] = append(grouped[command.group], command)
+		width = max(width, len(command.name))
+	}
+	for _, group := range commandGroups {
+		list := grouped[group]
+		if len(list) == 0 {
+			continue
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+		fmt.Fprintf(w, "%s:\n", configure.tr(group))
+		for _, command := range list {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, command.name, configure.tr(command.description))
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// writeCommandHelp writes the full help page of a command.
+func writeCommandHelp(w io.Writer, configure *config, command Commands) {
+	fmt.Fprintf(w, "%s - %s\n\n", command.name, configure.tr(command.description))
+	fmt.Fprintf(w, "%s\n  %s\n", configure.tr("usage:"), command.usage)
+	if len(command.args) > 0 {
+		width := 0
+		for _, arg := range command.args {
+			width = max(width, len(arg.name))
+		}
+		fmt.Fprintf(w, "\n%s\n", configure.tr("arguments:"))
+		for _, arg := range command.args {
+			fmt.Fprintf(w, "  %-*s  %s\n", width, arg.name, configure.tr(arg.description))
+		}
+	}
+	if len(command.examples) > 0 {
+		fmt.Fprintf(w, "\n%s\n", configure.tr("examples:"))
+		for _, example := range command.examples {
+			fmt.Fprintf(w, "  %s\n", example)
+		}
+	}
+	if len(command.aliases) > 0 {
+		fmt.Fprintf(w, "\n%s %s\n", configure.tr("aliases:"), strings.Join(command.aliases, ", "))
+	}
+}
+
+// usageError is returned by commands called with the wrong arguments; the
+// text comes from the command's usage so the two never disagree.
+func usageError(configure *config, name string) error {
+	return fmt.Errorf("%s %s, see help %s", configure.tr("usage:"), configure.commands[name].usage, name)
+}
@@ -0,0 +1,154 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const assetIndex = "index.json"
+
+// AssetCache keeps large binary assets such as sprites and artwork on disk
+// together with their content type. It has its own size budget, separate from
+// the in-memory JSON cache, and drops the least recently used assets once the
+// budget is exceeded.
+type AssetCache struct {
+	dir      string
+	maxBytes int64
+	mu       sync.Mutex
+	entries  map[string]*assetEntry
+	size     int64
+}
+
+type assetEntry struct {
+	File        string    `json:"file"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	LastUsed    time.Time `json:"last_used"`
+}
+
+// NewAssetCache opens (or creates) an asset cache stored in dir.
+func NewAssetCache(dir string, maxBytes int64) (*AssetCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	a := &AssetCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*assetEntry),
+	}
+	data, err := os.ReadFile(filepath.Join(dir, assetIndex))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &a.entries); err != nil {
+			// a broken index only costs us a re-download
+			a.entries = make(map[string]*assetEntry)
+		}
+	}
+	for key, entry := range a.entries {
+		if _, err := os.Stat(filepath.Join(dir, entry.File)); err != nil {
+			delete(a.entries, key)
+			continue
+		}
+		a.size += entry.Size
+	}
+	return a, nil
+}
+
+// Add stores value under key, replacing any previous asset.
+func (a *AssetCache) Add(key, contentType string, value []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(key))
+	file := hex.EncodeToString(sum[:])
+	if err := os.WriteFile(filepath.Join(a.dir, file), value, 0o644); err != nil {
+		return err
+	}
+	if old, exists := a.entries[key]; exists {
+		a.size -= old.Size
+	}
+	a.entries[key] = &assetEntry{
+		File:        file,
+		ContentType: contentType,
+		Size:        int64(len(value)),
+		LastUsed:    time.Now(),
+	}
+	a.size += int64(len(value))
+	a.evict(key)
+	return a.saveIndex()
+}
+
+// Get returns the asset stored under key and its content type.
+func (a *AssetCache) Get(key string) ([]byte, string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, exists := a.entries[key]
+	if !exists {
+		return nil, "", false
+	}
+	data, err := os.ReadFile(filepath.Join(a.dir, entry.File))
+	if err != nil {
+		a.size -= entry.Size
+		delete(a.entries, key)
+		return nil, "", false
+	}
+	entry.LastUsed = time.Now()
+	return data, entry.ContentType, true
+}
+
+// Size is the number of bytes currently stored.
+func (a *AssetCache) Size() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.size
+}
+
+// Keys lists the keys of the stored assets.
+func (a *AssetCache) Keys() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	keys := make([]string, 0, len(a.entries))
+	for key := range a.entries {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// evict removes the least recently used assets until the cache fits its
+// budget, never removing keep.
+func (a *AssetCache) evict(keep string) {
+	for a.size > a.maxBytes {
+		oldestKey := ""
+		var oldest *assetEntry
+		for key, entry := range a.entries {
+			if key == keep {
+				continue
+			}
+			if oldest == nil || entry.LastUsed.Before(oldest.LastUsed) {
+				oldestKey, oldest = key, entry
+			}
+		}
+		if oldest == nil {
+			return
+		}
+		os.Remove(filepath.Join(a.dir, oldest.File))
+		a.size -= oldest.Size
+		delete(a.entries, oldestKey)
+	}
+}
+
+func (a *AssetCache) saveIndex() error {
+	data, err := json.Marshal(a.entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(a.dir, assetIndex), data, 0o644)
+}
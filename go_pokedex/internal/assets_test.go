@@ -0,0 +1,101 @@
+package pokecache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAssetCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	a, err := NewAssetCache(t.TempDir(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"a", "b"} {
+		if err := a.Add(key, "image/png", []byte("1234")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, _, ok := a.Get("a"); !ok {
+		t.Fatal("a missing before the budget was exceeded")
+	}
+	if err := a.Add("c", "image/png", []byte("1234")); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := a.Get("b"); ok {
+		t.Error("b was kept although it was the least recently used")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, _, ok := a.Get(key); !ok {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+	if got := a.Size(); got != 8 {
+		t.Errorf("Size() = %d, want 8", got)
+	}
+}
+
+func TestAssetCacheKeepsOversizedAsset(t *testing.T) {
+	a, err := NewAssetCache(t.TempDir(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Add("small", "image/png", []byte("12")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Add("big", "image/png", []byte("123456")); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := a.Get("big"); !ok {
+		t.Error("the asset just added was evicted")
+	}
+	if _, _, ok := a.Get("small"); ok {
+		t.Error("small was kept although the cache is over budget")
+	}
+	if got := a.Size(); got != 6 {
+		t.Errorf("Size() = %d, want 6", got)
+	}
+}
+
+func TestAssetCacheReloadsIndex(t *testing.T) {
+	dir := t.TempDir()
+	a, err := NewAssetCache(dir, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Add("pikachu", "image/png", []byte("png data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Add("gone", "image/gif", []byte("gif")); err != nil {
+		t.Fatal(err)
+	}
+	// an asset whose file disappeared is dropped on reload
+	os.Remove(filepath.Join(dir, a.entries["gone"].File))
+
+	reloaded, err := NewAssetCache(dir, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, contentType, ok := reloaded.Get("pikachu")
+	if !ok || string(data) != "png data" || contentType != "image/png" {
+		t.Errorf("Get(pikachu) after reload = %q, %q, %v", data, contentType, ok)
+	}
+	if _, _, ok := reloaded.Get("gone"); ok {
+		t.Error("asset with a missing file survived the reload")
+	}
+	if got := reloaded.Size(); got != int64(len("png data")) {
+		t.Errorf("Size() after reload = %d, want %d", got, len("png data"))
+	}
+
+	// a damaged index starts an empty cache rather than failing
+	if err := os.WriteFile(filepath.Join(dir, assetIndex), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	broken, err := NewAssetCache(dir, 100)
+	if err != nil {
+		t.Fatalf("NewAssetCache with a damaged index: %v", err)
+	}
+	if got := broken.Size(); got != 0 {
+		t.Errorf("Size() with a damaged index = %d, want 0", got)
+	}
+}
@@ -8,6 +8,8 @@ type Cache struct{
 	Cargo   map[string]cacheEntry
 	mu   sync.Mutex
 	interval time.Duration
+	// Assets holds binary downloads such as sprites, nil keeps them in Cargo
+	Assets  *AssetCache
 }
 
 
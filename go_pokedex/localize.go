@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// apiNames is the shape of the "names" arrays PokeAPI resources carry, one
+// translated name per language.
+type apiNames = []struct {
+	Name     string `json:"name"`
+	Language struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"language"`
+}
+
+// pickName returns the name in lang, then in English, then the slug.
+func pickName(names apiNames, lang, slug string) string {
+	english := ""
+	for _, n := range names {
+		if n.Language.Name == lang && n.Name != "" {
+			return n.Name
+		}
+		if n.Language.Name == "en" {
+			english = n.Name
+		}
+	}
+	if english != "" {
+		return english
+	}
+	return slug
+}
+
+// localize returns the display name of a PokeAPI resource such as
+// ("pokemon-species", "pikachu") or ("move", "thunder-shock") in the
+// trainer's language. Without a language set the slug is shown as before.
+func (configure *config) localize(c *pokecache.Cache, kind, slug string) string {
+	if configure.language == "" || slug == "" {
+		return slug
+	}
+	key := kind + "/" + slug
+	if name, ok := configure.localNames[key]; ok {
+		return name
+	}
+	resource := struct {
+		Names apiNames `json:"names"`
+	}{}
+	name := slug
+	if err := fetchJSON(c, pokeAPI+kind+"/"+slug, &resource); err == nil {
+		name = pickName(resource.Names, configure.language, slug)
+	}
+	configure.localNames[key] = name
+	return name
+}
+
+// localizeListed is localize for lists of names the trainer types back into
+// other commands: the slug follows the localized name when they differ.
+func (configure *config) localizeListed(c *pokecache.Cache, kind, slug string) string {
+	if name := configure.localize(c, kind, slug); name != slug {
+		return fmt.Sprintf("%s (%s)", name, slug)
+	}
+	return slug
+}
+
+// pokemonName localizes a Pokemon through its species.
+func (configure *config) pokemonName(c *pokecache.Cache, name string) string {
+	return configure.localize(c, "pokemon-species", name)
+}
+
+// tr translates one of the REPL's own messages through the catalog, falling
+// back to the English text.
+func (configure *config) tr(msg string) string {
+	if translated, ok := messageCatalog[configure.language][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+func commandLanguage(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) == 0 {
+		if configure.language == "" {
+			fmt.Println(configure.tr("no language set, showing names as PokeAPI slugs"))
+		} else {
+			fmt.Printf(configure.tr("current language: %s")+"\n", configure.language)
+		}
+		return nil
+	}
+	if args[0] == "none" {
+		configure.language = ""
+		configure.localNames = make(map[string]string)
+		return nil
+	}
+	err := fetchJSON(c, pokeAPI+"language/"+args[0], &struct{}{})
+	if err == errNotFound {
+		return notFoundError(configure, c, "language", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	configure.language = args[0]
+	configure.localNames = make(map[string]string)
+	fmt.Printf(configure.tr("language set to %s")+"\n", args[0])
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"encoding/json"
@@ -15,16 +16,43 @@ import (
 )
 
 
+// assetBudget is how many bytes of sprites and artwork are kept on disk.
+const assetBudget = 64 << 20
+
 type Commands struct {
 	name        string
-	description string
+	description string   // one line summary for the listing
+	usage       string   // synopsis, <required> and [optional] arguments
+	args        []argSpec
+	examples    []string
+	aliases     []string // other names the command can be typed as
+	group       string   // heading the command is listed under, see commandGroups
+	rawArgs     bool     // keep the case of the arguments, e.g. for file paths
+	saves       bool     // changes the trainer's state, the profile is saved after it runs
 	function    func(configure *config, c *pokecache.Cache, AreaName string) error
 }
 
 type config struct {
 	id  int
 	history [][]string // Keep track of the history of pages (slice of slices)
-	caughtPokemon   map[string]Pokemon
+	caughtPokemon   map[string]*OwnedPokemon
+	registered map[string]bool // species still in the pokedex after evolving, see evolve
+	party   []string // names of caught pokemon, the first one leads in battle
+	area    string   // current location area, set by travel and explore
+	inventory map[string]int
+	names   map[string][]string // name indexes by kind, see nameIndex
+	game    versionFilter       // current game version, empty for every game
+	aliases map[string]string   // user aliases, name -> command line
+	macros  map[string]string   // user macros, name -> ;-separated commands
+	commands map[string]Commands // built once at startup, see get_commands and addPlugins
+	profile string               // active trainer profile, empty when progress is not saved
+	trades  []tradeRecord
+	pending *pendingTrade // trade being committed, see runTrade
+	unsaved bool // a command changed the state since the profile was saved
+	language string             // PokeAPI language code for names and messages, empty for slugs
+	localNames map[string]string // localized names by kind/slug, see localize
+	rng     *rand.Rand
+	input   *bufio.Scanner
 }
 
 type locale_area struct {
@@ -67,11 +95,14 @@ type locale_area struct {
 			} `json:"version"`
 			MaxChance        int `json:"max_chance"`
 			EncounterDetails []struct {
-				MinLevel        int   `json:"min_level"`
-				MaxLevel        int   `json:"max_level"`
-				ConditionValues []any `json:"condition_values"`
-				Chance          int   `json:"chance"`
-				Method          struct {
+				MinLevel        int `json:"min_level"`
+				MaxLevel        int `json:"max_level"`
+				ConditionValues []struct {
+					Name string `json:"name"`
+					URL  string `json:"url"`
+				} `json:"condition_values"`
+				Chance int `json:"chance"`
+				Method struct {
 					Name string `json:"name"`
 					URL  string `json:"url"`
 				} `json:"method"`
@@ -361,19 +392,46 @@ type Pokemon struct {
 
 
 func main() {
-	time := time.Duration(30 * time.Second)
-	configure := &config{history: make([][]string, 0), id: 1, caughtPokemon: make(map[string]Pokemon)} // Initialize history as a slice of slices
-	start_repl(configure,time)
+	interval := time.Duration(30 * time.Second)
+	configure := &config{history: make([][]string, 0), id: 1, caughtPokemon: make(map[string]*OwnedPokemon), inventory: make(map[string]int), names: make(map[string][]string), localNames: make(map[string]string), aliases: make(map[string]string), macros: make(map[string]string), rng: rand.New(rand.NewSource(time.Now().UnixNano()))} // Initialize history as a slice of slices
+	configure.commands = get_commands(configure)
+	if err := loadActiveProfile(configure); err != nil{
+		fmt.Println("unable to load your profile:", err)
+	}
+	addPlugins(configure.commands)
+	if len(os.Args) > 1 && (os.Args[1] == "--help" || os.Args[1] == "-h"){
+		fmt.Println("usage: go_pokedex [serve [--addr=host:port]]")
+		fmt.Println()
+		fmt.Println("starts an interactive pokedex, these commands are available at its prompt:")
+		fmt.Println()
+		writeCommandList(os.Stdout, configure, configure.commands)
+		fmt.Println("serve shows the pokedex in a browser and answers map, explore, catch, inspect and pokedex as a JSON API, see /api/openapi.json")
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve"{
+		if err := serve(configure, newCache(interval), os.Args[2:]); err != nil{
+			fmt.Println(configure.tr("Error"), err)
+			os.Exit(1)
+		}
+		return
+	}
+	start_repl(configure,interval)
 }
 
 func commandHelp(configure *config, c *pokecache.Cache, AreaName string) error {
-	fmt.Println("welcome to the pokedex!\n")
-	fmt.Println("usage:\n")
-	commandsInput := get_commands(configure)
-	for i, v := range commandsInput {
-		fmt.Printf("%s: %s\n", i, v.description)
+	commandsInput := configure.commands
+	if len(AreaName) != 0 {
+		command, exists := lookupCommand(commandsInput, AreaName)
+		if !exists {
+			return fmt.Errorf("no command named %s", AreaName)
+		}
+		writeCommandHelp(os.Stdout, configure, command)
+		return nil
 	}
+	fmt.Println(configure.tr("welcome to the pokedex!"))
 	fmt.Println()
+	writeCommandList(os.Stdout, configure, commandsInput)
+	fmt.Println(configure.tr("type help <command> or <command> --help for its arguments and examples"))
 	return nil
 }
 
@@ -382,36 +440,89 @@ func commandExit(configure *config, c *pokecache.Cache, AreaName string) error {
 	return nil
 }
 
+// newCache creates the response cache, keeping sprites on disk when there is
+// a user cache directory.
+func newCache(inter time.Duration) *pokecache.Cache {
+	c := pokecache.NewCache(inter)
+	if dir, err := os.UserCacheDir(); err == nil{
+		assets, err := pokecache.NewAssetCache(filepath.Join(dir, "go_pokedex", "assets"), assetBudget)
+		if err != nil{
+			fmt.Println("sprites will not be kept on disk:", err)
+		}else{
+			c.Assets = assets
+		}
+	}
+	return c
+}
+
 func start_repl(configure *config, inter time.Duration) {
 	input := bufio.NewScanner(os.Stdin)
-	c := pokecache.NewCache(inter)
+	configure.input = input
+	c := newCache(inter)
+	if err := loadAliases(configure); err != nil{
+		fmt.Println(configure.tr("unable to load your aliases:"), err)
+	}
 	for {
-		fmt.Print("pokedex > ")
-		input.Scan()
-		commandParts := cleanInput(input.Text())
-		if len(commandParts) == 0 {
-			continue
+		fmt.Print(promptText(configure))
+		if !input.Scan() {
+			return
 		}
-		commandText := commandParts[0]
-		area := ""
-		if len(commandParts) < 2{
-			area = ""
-		}else{
-			area = commandParts[1]
+		runLine(configure, c, input.Text(), 0)
+		if configure.unsaved{
+			if err := saveProfile(configure); err != nil{
+				fmt.Println(configure.tr("unable to save your profile:"), err)
+			}else{
+				configure.unsaved = false
+			}
 		}
-		
-		
-		// Get the command from the map
-		command, exists := get_commands(configure)[commandText]
+	}
+}
 
-		if exists {
-			// Call the function associated with the command
-			if err := command.function(configure, c, area); err != nil {
-				fmt.Println("Error", err)
-			}
-		} else {
-			fmt.Println("no such command")
+// runLine runs one line of input, expanding user aliases and macros first.
+func runLine(configure *config, c *pokecache.Cache, line string, depth int) {
+	commandParts := cleanInput(line)
+	if len(commandParts) == 0 {
+		return
+	}
+	commandText := commandParts[0]
+	area := strings.Join(commandParts[1:], " ")
+	// the arguments as typed, for commands that take file names or text
+	rawParts := strings.Fields(line)[1:]
+
+	_, isAlias := configure.aliases[commandText]
+	_, isMacro := configure.macros[commandText]
+	if (isAlias || isMacro) && depth >= maxExpansion {
+		fmt.Println(configure.tr("Error"), commandText, "expands too deeply, check your aliases and macros")
+		return
+	}
+	if isAlias {
+		runLine(configure, c, configure.aliases[commandText] + " " + strings.Join(rawParts, " "), depth+1)
+		return
+	}
+	if isMacro {
+		for _, step := range expandMacro(configure.macros[commandText], rawParts) {
+			runLine(configure, c, step, depth+1)
+		}
+		return
+	}
+
+	// Get the command from the map
+	command, exists := lookupCommand(configure.commands, commandText)
+	if exists && command.rawArgs {
+		area = strings.Join(rawParts, " ")
+	}
+
+	if exists && wantsHelp(area) {
+		writeCommandHelp(os.Stdout, configure, command)
+	} else if exists {
+		// a failed command may still have changed something, save either way
+		configure.unsaved = configure.unsaved || command.saves
+		// Call the function associated with the command
+		if err := command.function(configure, c, area); err != nil {
+			fmt.Println(configure.tr("Error"), err)
 		}
+	} else {
+		fmt.Println(configure.tr("no such command"))
 	}
 }
 
@@ -421,6 +532,35 @@ func cleanInput(text string) []string {
 	return words
 }
 
+// parseArgs splits command arguments into --key=value flags (a bare --key maps
+// to "") and positional arguments.
+func parseArgs(text string) (map[string]string, []string) {
+	flags := make(map[string]string)
+	args := make([]string, 0)
+	for _, word := range strings.Fields(text) {
+		if !strings.HasPrefix(word, "--") {
+			args = append(args, word)
+			continue
+		}
+		key, value, _ := strings.Cut(strings.TrimPrefix(word, "--"), "=")
+		flags[key] = value
+	}
+	return flags, args
+}
+
+// prompt asks the user for a line of input while a command is running.
+func prompt(configure *config, text string) string {
+	if configure.input == nil {
+		// nobody to ask, e.g. in serve mode
+		return ""
+	}
+	fmt.Print(text)
+	if !configure.input.Scan() {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(configure.input.Text()))
+}
+
 func commandMap(configure *config, c *pokecache.Cache, AreaName string) error {
 	// Save the current page of URLs to history before loading new ones
 	id_old := configure.id
@@ -431,7 +571,7 @@ func commandMap(configure *config, c *pokecache.Cache, AreaName string) error {
 			break
 		}
 		id_string := strconv.Itoa(configure.id)
-		url := "https://pokeapi.co/api/v2/location-area/" + id_string
+		url := pokeAPI + "location-area/" + id_string
 		if _, exist := c.Cargo[url]; exist{
 			body, succ := c.Get(url)
 			if  succ != true {
@@ -481,7 +621,7 @@ func commandMap(configure *config, c *pokecache.Cache, AreaName string) error {
 func commandMapB(configure *config, c *pokecache.Cache, AreaName string) error {
 	// Check if there is a previous page in history
 	if len(configure.history) == 0 {
-		fmt.Println("No previous pages to go back to.")
+		fmt.Println(configure.tr("No previous pages to go back to."))
 		return nil
 	}
 
@@ -506,7 +646,7 @@ func commandMapB(configure *config, c *pokecache.Cache, AreaName string) error {
 			}
 			fmt.Println(location.Name)
 		} else {
-			fmt.Printf("No records for %s\n", url)
+			fmt.Printf(configure.tr("No records for %s")+"\n", url)
 		}
 	}
 
@@ -514,68 +654,56 @@ func commandMapB(configure *config, c *pokecache.Cache, AreaName string) error {
 }
 
 func commandExplore(configure *config, c *pokecache.Cache, AreaName string) error{
-	if len(AreaName) == 0{
-		return fmt.Errorf("no location")
-	}
-	if body, exists := c.Get(AreaName); exists{
-		location := locale_area{}
-		errs := json.Unmarshal(body, &location)
-		if errs != nil{
-			return fmt.Errorf("unmarshal error")
-		}
-		fmt.Println("pokemon found:")
-		for _, v := range location.PokemonEncounters{
-			fmt.Println(v.Pokemon.Name)
-		}
-		return nil
-	}else{
-		res, err := http.Get("https://pokeapi.co/api/v2/location-area/" + AreaName)
-		if err != nil{
-			return fmt.Errorf("unable to get pokemon in area")
-		
-		}
-		body, err := io.ReadAll(res.Body)
-		defer res.Body.Close()
-		c.Add(AreaName, body)
-		if res.StatusCode > 299{
-			fmt.Printf("Error status code: %v", res.StatusCode)
-		}
-		if err !=nil{
-			return fmt.Errorf("unable to parse json")
-		}
-		location := locale_area{}
-		errs := json.Unmarshal(body, &location)
-		if errs != nil{
-			return fmt.Errorf("unmarshal error")
-		}
-		fmt.Println("pokemon found:")
-		for _, v := range location.PokemonEncounters{
-			fmt.Println(v.Pokemon.Name)
+	flags, args := parseArgs(AreaName)
+	if len(args) == 0{
+		if configure.area == ""{
+			return fmt.Errorf("no location, travel somewhere or name an area")
 		}
+		args = []string{configure.area}
 	}
-	return nil
-}
-
-func commandCatch(configure *config, c *pokecache.Cache, AreaName string) error{
-	res, err := http.Get("https://pokeapi.co/api/v2/pokemon/" + AreaName)
+	filter, err := gameFilter(configure, c, flags)
 	if err != nil{
-		return fmt.Errorf("Error getting pokemon:%v", err)
+		return err
 	}
-	body , err := io.ReadAll(res.Body)
-	defer res.Body.Close()
-	if res.StatusCode > 299{
-		return fmt.Errorf("error on Get request: %v", res.StatusCode)
+	location, err := fetchLocationArea(c, args[0])
+	if err == errNotFound{
+		return notFoundError(configure, c, "area", args[0])
 	}
 	if err != nil{
-		return fmt.Errorf("error: %v", err)
+		return fmt.Errorf("unable to get pokemon in area: %v", err)
+	}
+	configure.area = location.Name
+	return printEncounters(configure, c, location, filter)
+}
+
+func commandCatch(configure *config, c *pokecache.Cache, AreaName string) error{
+	poke, err := fetchPokemon(c, AreaName)
+	if err == errNotFound{
+		return notFoundError(configure, c, "pokemon", AreaName)
 	}
-	poke := Pokemon{}
-	errs := json.Unmarshal(body, &poke)
-	if errs != nil{
-		return fmt.Errorf("error parsing json %v", errs)
+	if err != nil{
+		return fmt.Errorf("Error getting pokemon:%v", err)
 	}
-	fmt.Printf("threw a pokeball at %s\n", poke.Name)
-	chance := rand.Intn(11)
+	_, err = throwPokeball(configure, c, poke, os.Stdout)
+	return err
+}
+
+// catchResult is the outcome of a pokeball throw.
+type catchResult struct {
+	Pokemon           string        `json:"pokemon"`
+	Caught            bool          `json:"caught"`
+	AlreadyRegistered bool          `json:"already_registered"`
+	JoinedParty       bool          `json:"joined_party"`
+	Owned             *OwnedPokemon `json:"-"`
+}
+
+// throwPokeball tries to catch poke, registering it in the pokedex and party
+// on success, and narrates the throw to w.
+func throwPokeball(configure *config, c *pokecache.Cache, poke Pokemon, w io.Writer) (catchResult, error){
+	result := catchResult{Pokemon: poke.Name}
+	display := configure.pokemonName(c, poke.Name)
+	fmt.Fprintf(w, configure.tr("threw a pokeball at %s")+"\n", display)
+	chance := configure.rng.Intn(11)
 	caught := false
 	if poke.BaseExperience >= 250 {
         if chance >= 9 {
@@ -594,52 +722,172 @@ func commandCatch(configure *config, c *pokecache.Cache, AreaName string) error{
             caught = true
         }
     }
+	result.Caught = caught
 	if caught{
-		fmt.Printf("caught %s\n", poke.Name)
+		fmt.Fprintf(w, configure.tr("caught %s")+"\n", display)
 		if _,exists := configure.caughtPokemon[poke.Name]; exists{
-			fmt.Println("already registered in pokedex")
+			result.AlreadyRegistered = true
+			fmt.Fprintln(w, configure.tr("already registered in pokedex"))
 		}else{
-			configure.caughtPokemon[poke.Name] = poke
+			owned := newOwnedPokemon(configure.rng, poke, catchLevel(configure.rng), configure.game)
+			if err := owned.loadSpecies(c, configure.rng); err != nil{
+				return result, fmt.Errorf("error getting species: %v", err)
+			}
+			if owned.Shiny{
+				fmt.Fprintf(w, configure.tr("%s is shiny!")+"\n", display)
+			}
+			if len(configure.party) > 0{
+				if err := gainExperience(configure, c, configure.caughtPokemon[configure.party[0]], expYield(owned), w); err != nil{
+					return result, err
+				}
+			}
+			owned.CaughtAt = time.Now()
+			owned.CaughtIn = configure.area
+			configure.caughtPokemon[poke.Name] = owned
+			delete(configure.registered, poke.Name)
+			result.Owned = owned
+			fmt.Fprintf(w, configure.tr("%s is a level %d %s")+"\n", display, owned.Level, configure.tr(owned.Gender))
+			if len(configure.party) < 6{
+				configure.party = append(configure.party, poke.Name)
+				result.JoinedParty = true
+				fmt.Fprintf(w, configure.tr("%s joined your party")+"\n", display)
+			}
 		}
 	}else{
-		fmt.Printf("%s escaped!\n", poke.Name)
+		fmt.Fprintf(w, configure.tr("%s escaped!")+"\n", display)
 	}
 	
-	return nil
+	return result, nil
 }
 
 
 func commandInspect(configure *config, c *pokecache.Cache, AreaName string) error{
-	if InspectMon, exists := configure.caughtPokemon[AreaName]; exists{
-		fmt.Println("Name: " + InspectMon.Name)
-		fmt.Printf("Height: %v\n", InspectMon.Height)
-		fmt.Printf("Weight: %v\n", InspectMon.Weight)
-		fmt.Println("Stats:")
-		for _, v := range InspectMon.Stats{
-			fmt.Printf("	-%v: %v\n", v.Stat.Name, v.BaseStat)
+	flags, args := parseArgs(AreaName)
+	name := ""
+	if len(args) > 0{
+		name = args[0]
+	}
+	if InspectMon, exists := configure.caughtPokemon[name]; exists{
+		info := make([]string, 0)
+		info = append(info, "Name: " + configure.pokemonName(c, InspectMon.Name))
+		info = append(info, "UID: " + InspectMon.UID)
+		info = append(info, fmt.Sprintf("Gender: %v %v", InspectMon.Gender, InspectMon.genderSymbol()))
+		if InspectMon.Shiny{
+			info = append(info, "Shiny: yes ★")
+		}
+		info = append(info, fmt.Sprintf("Level: %v", InspectMon.Level))
+		if InspectMon.Level < maxLevel{
+			info = append(info, fmt.Sprintf("Exp: %v (next level at %v)", InspectMon.Exp, expForLevel(InspectMon.GrowthRate, InspectMon.Level+1)))
+		}else{
+			info = append(info, fmt.Sprintf("Exp: %v", InspectMon.Exp))
+		}
+		if n, ok := findNature(InspectMon.Nature); ok && n.up != n.down{
+			info = append(info, fmt.Sprintf("Nature: %v (+%v, -%v)", n.name, n.up, n.down))
+		}else{
+			info = append(info, fmt.Sprintf("Nature: %v", InspectMon.Nature))
 		}
-		fmt.Println("Types:")
+		info = append(info, fmt.Sprintf("Friendship: %v", InspectMon.Friendship))
+		info = append(info, fmt.Sprintf("Height: %v", InspectMon.Height))
+		info = append(info, fmt.Sprintf("Weight: %v", InspectMon.Weight))
+		info = append(info, "Stats: base -> actual")
+		for _, name := range statNames{
+			info = append(info, fmt.Sprintf("  -%v: %v -> %v (IV %v, EV %v)", name, InspectMon.baseStat(name), InspectMon.stat(name), InspectMon.IVs[name], InspectMon.EVs[name]))
+		}
+		info = append(info, "Types:")
 		for _, h := range InspectMon.Types{
-			fmt.Printf("	-%v\n",h.Type.Name)
+			info = append(info, fmt.Sprintf("  -%v", configure.localize(c, "type", h.Type.Name)))
+		}
+		info = append(info, "Moves:")
+		for _, m := range InspectMon.KnownMoves{
+			info = append(info, fmt.Sprintf("  -%v", configure.localize(c, "move", m)))
+		}
+
+		selector := "front"
+		filter, err := gameFilter(configure, c, flags)
+		if err != nil{
+			return err
+		}
+		if v, ok := versionGroupSprites[filter.Group]; ok && spriteSelectors[v](InspectMon) != ""{
+			selector = v
+		}
+		if v, ok := flags["sprite"]; ok{
+			selector = v
+		}
+		if selector == "none"{
+			printBeside(nil, 0, info)
+			return nil
 		}
+		art, width, err := loadSprite(c, InspectMon, selector)
+		if err != nil{
+			// the stats are still worth showing without the picture
+			fmt.Printf("("+configure.tr("no sprite: %v")+")\n", err)
+		}
+		printBeside(art, width, info)
 	}else{
-		fmt.Println("you have not caught that pokemon")
+		fmt.Println(configure.tr("you have not caught that pokemon") + notCaughtHint(configure, c, name))
 	}
 	return nil
 }
 
 
 func commandPokeDex(configure *config, c *pokecache.Cache, AreaName string) error{
-	fmt.Println("Your PokeDex:")
+	fmt.Println(configure.tr("Your PokeDex:"))
 	if len(configure.caughtPokemon) == 0{
-		fmt.Println("You have not caught any pokemon yet, catch some with the catch command")
+		fmt.Println(configure.tr("You have not caught any pokemon yet, catch some with the catch command"))
 	}
+	shiny := 0
+	genders := make(map[string]int)
 	for _, v := range configure.caughtPokemon{
-		fmt.Printf(" -%v\n", v.Name)
+		mark := ""
+		if v.Shiny{
+			mark = " ★"
+			shiny++
+		}
+		fmt.Printf(" -%v %v%v\n", configure.pokemonName(c, v.Name), v.genderSymbol(), mark)
+		genders[v.Gender]++
+	}
+	for _, name := range sortedKeys(configure.registered){
+		fmt.Printf(" -%v %s\n", configure.pokemonName(c, name), configure.tr("(evolved)"))
+	}
+	if len(configure.caughtPokemon) > 0{
+		fmt.Printf(configure.tr("Caught %d pokemon (%d male, %d female, %d genderless), %d shiny")+"\n", len(configure.caughtPokemon), genders["male"], genders["female"], genders["genderless"], shiny)
+	}
+	return nil
+}
+
+func commandParty(configure *config, c *pokecache.Cache, AreaName string) error{
+	if len(AreaName) != 0{
+		for i, name := range configure.party{
+			if name == AreaName{
+				configure.party = append(configure.party[:i], configure.party[i+1:]...)
+				configure.party = append([]string{name}, configure.party...)
+				fmt.Printf(configure.tr("%s now leads your party")+"\n", configure.pokemonName(c, name))
+				return nil
+			}
+		}
+		return fmt.Errorf("%s is not in your party", AreaName)
+	}
+	fmt.Println(configure.tr("Your party:"))
+	if len(configure.party) == 0{
+		fmt.Println(configure.tr("Your party is empty, catch some pokemon with the catch command"))
+	}
+	for i, name := range configure.party{
+		mon := configure.caughtPokemon[name]
+		fmt.Printf(" %d) %v Lv%v\n", i+1, configure.pokemonName(c, mon.Name), mon.Level)
 	}
 	return nil
 }
 
+func commandBag(configure *config, c *pokecache.Cache, AreaName string) error{
+	fmt.Println(configure.tr("Your bag:"))
+	if len(configure.inventory) == 0{
+		fmt.Println(configure.tr("Your bag is empty, wild pokemon sometimes drop the items they hold"))
+	}
+	for item, count := range configure.inventory{
+		fmt.Printf(" -%v x%v\n", configure.localize(c, "item", item), count)
+	}
+	return nil
+}
 
 
 func get_commands(configure *config) map[string]Commands {
@@ -647,42 +895,364 @@ func get_commands(configure *config) map[string]Commands {
 		"help": {
 			name:        "help",
 			description: "show help on commands",
+			usage:       "help [command]",
+			args:        []argSpec{{"command", "a command to show the full help page of"}},
+			examples:    []string{"help", "help explore", "explore --help"},
+			group:       "general",
 			function:    commandHelp,
 		},
 		"exit": {
 			name:        "exit",
 			description: "exit the program",
+			usage:       "exit",
+			group:       "general",
 			function:    commandExit,
 		},
 		"map": {
 			name:        "map",
 			description: "list locations",
+			usage:       "map",
+			group:       "world",
+			saves:       true,
 			function:    commandMap,
 		},
 		"mapb": {
 			name:        "mapb",
 			description: "go back a page when seeing location",
+			usage:       "mapb",
+			group:       "world",
+			saves:       true,
 			function:    commandMapB,
 		},
 		"explore": {
-			name: "explore",
-			description: "list pokemon in area",
-			function:  commandExplore,
+			name:        "explore",
+			description: "list the pokemon in an area",
+			usage:       "explore [area] [--version=name]",
+			args: []argSpec{
+				{"area", "the location area to explore, defaults to your current area"},
+				{"--version=name", "only show encounters of this game version, all for every version"},
+			},
+			examples: []string{"explore viridian-forest-area", "explore --version=yellow"},
+			aliases:  []string{"e"},
+			group:    "world",
+			saves:    true,
+			function: commandExplore,
+		},
+		"regions": {
+			name:        "regions",
+			description: "list the regions",
+			usage:       "regions",
+			group:       "world",
+			function:    commandRegions,
+		},
+		"locations": {
+			name:        "locations",
+			description: "list the locations of a region",
+			usage:       "locations <region>",
+			args:        []argSpec{{"region", "a region from the regions command"}},
+			examples:    []string{"locations kanto"},
+			group:       "world",
+			function:    commandLocations,
+		},
+		"areas": {
+			name:        "areas",
+			description: "list the areas of a location",
+			usage:       "areas <location>",
+			args:        []argSpec{{"location", "a location from the locations command"}},
+			examples:    []string{"areas viridian-forest"},
+			group:       "world",
+			function:    commandAreas,
+		},
+		"travel": {
+			name:        "travel",
+			description: "move to an area, explore and battle then use it",
+			usage:       "travel <area>",
+			args:        []argSpec{{"area", "a location area from the areas command"}},
+			examples:    []string{"travel viridian-forest-area"},
+			group:       "world",
+			saves:       true,
+			function:    commandTravel,
+		},
+		"where": {
+			name:        "where",
+			description: "list the areas a pokemon can be found in",
+			usage:       "where <pokemon> [--version=name]",
+			args: []argSpec{
+				{"pokemon", "the pokemon to look for"},
+				{"--version=name", "only show this game version, all for every version"},
+			},
+			examples: []string{"where pikachu", "where pikachu --version=yellow"},
+			group:    "world",
+			saves:    true, // choosing an area explores it, which moves you there
+			function: commandWhere,
 		},
 		"catch": {
-			name: "catch",
+			name:        "catch",
 			description: "catch a specific pokemon at a chance to add to the pokedex",
-			function: commandCatch,
+			usage:       "catch <pokemon>",
+			args:        []argSpec{{"pokemon", "the pokemon to throw a pokeball at"}},
+			examples:    []string{"catch pikachu"},
+			aliases:     []string{"c"},
+			group:       "pokemon",
+			saves:       true,
+			function:    commandCatch,
 		},
-		"inspect":{
-			name: "inspect",
-			description: "inspect a certain pokemon",
+		"inspect": {
+			name:        "inspect",
+			description: "show the stats and sprite of a caught pokemon",
+			usage:       "inspect <pokemon> [--sprite=name] [--version=name]",
+			args: []argSpec{
+				{"pokemon", "a pokemon from your pokedex"},
+				{"--sprite=name", "front, back, shiny, gen1-red-blue, ... picks the picture, none hides it"},
+				{"--version=name", "show the sprite of this game version"},
+			},
+			examples: []string{"inspect pikachu", "inspect pikachu --sprite=back", "inspect pikachu --sprite=none"},
+			aliases:  []string{"i"},
+			group:    "pokemon",
 			function: commandInspect,
 		},
-		"pokedex":{
-			name:"pokedex",
+		"pokedex": {
+			name:        "pokedex",
 			description: "list the whole caught pokedex",
-			function: commandPokeDex,
+			usage:       "pokedex",
+			aliases:     []string{"dex"},
+			group:       "pokemon",
+			function:    commandPokeDex,
+		},
+		"party": {
+			name:        "party",
+			description: "list your party or pick its lead",
+			usage:       "party [pokemon]",
+			args:        []argSpec{{"pokemon", "a party member to make the lead"}},
+			examples:    []string{"party", "party pikachu"},
+			group:       "pokemon",
+			saves:       true,
+			function:    commandParty,
+		},
+		"battle": {
+			name:        "battle",
+			description: "battle a wild pokemon with your lead",
+			usage:       "battle [pokemon] [--seed=N] [--auto] [--version=name]",
+			args: []argSpec{
+				{"pokemon", "the pokemon to battle, defaults to one from your current area"},
+				{"--seed=N", "seed the random numbers for a repeatable battle"},
+				{"--auto", "pick the strongest move every turn"},
+				{"--version=name", "only meet pokemon and held items of this game version"},
+			},
+			examples: []string{"battle", "battle rattata", "battle --seed=1 --auto"},
+			group:    "pokemon",
+			saves:    true,
+			function: commandBattle,
+		},
+		"trade": {
+			name:        "trade",
+			description: "trade a pokemon with a trainer on another pokedex",
+			usage:       "trade host|connect <address> | trade history | trade abandon",
+			args: []argSpec{
+				{"host <address>", "wait for the other trainer on host:port, or unix:/path for a Unix socket"},
+				{"connect <address>", "connect to a trainer who is hosting"},
+				{"history", "list your past trades"},
+				{"abandon", "give up a trade that was cut off, when its partner cannot connect again"},
+			},
+			examples: []string{"trade host :7777", "trade connect 192.168.1.20:7777", "trade history"},
+			group:    "pokemon",
+			saves:    true,
+			function: commandTrade,
+		},
+		"export-mon": {
+			name:        "export-mon",
+			description: "move a pokemon out of your box into a file signed with your trainer key",
+			usage:       "export-mon <uid|pokemon> <recipient key> [path]",
+			args: []argSpec{
+				{"uid|pokemon", "the pokemon to export, by the uid inspect shows or by name"},
+				{"recipient key", "the trainer key of the only trainer who may import the file, import-mon --key shows it"},
+				{"path", "the file to write, defaults to <pokemon>-<uid>.pokemon.json"},
+			},
+			examples: []string{"export-mon pikachu 9c4e...e1a0", "export-mon 3f2a9c1d0b7e4a56 9c4e...e1a0 gifts/pikachu.json"},
+			group:    "pokemon",
+			rawArgs:  true,
+			saves:    true,
+			function: commandExportMon,
+		},
+		"import-mon": {
+			name:        "import-mon",
+			description: "add a pokemon from a file made by export-mon",
+			usage:       "import-mon <file> | import-mon --key",
+			args: []argSpec{
+				{"file", "a file signed by its trainer and exported for your trainer key; each file imports once"},
+				{"--key", "show your trainer key, for the trainer exporting a pokemon to you"},
+			},
+			examples: []string{"import-mon pikachu-3f2a9c1d0b7e4a56.pokemon.json", "import-mon --key"},
+			group:    "pokemon",
+			rawArgs:  true,
+			saves:    true,
+			function: commandImportMon,
+		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "show the evolution chain of a pokemon as a tree",
+			usage:       "evolutions <pokemon>",
+			args:        []argSpec{{"pokemon", "any pokemon, caught or not"}},
+			examples:    []string{"evolutions pikachu"},
+			group:       "pokemon",
+			function:    commandEvolutions,
+		},
+		"use": {
+			name:        "use",
+			description: "use an item from your bag on a pokemon",
+			usage:       "use <item> <pokemon>",
+			args: []argSpec{
+				{"item", "an item in your bag, e.g. an evolution stone"},
+				{"pokemon", "a pokemon from your pokedex"},
+			},
+			examples: []string{"use thunder-stone pikachu"},
+			group:    "pokemon",
+			saves:    true,
+			function: commandUse,
+		},
+		"bag": {
+			name:        "bag",
+			description: "list the items in your bag",
+			usage:       "bag",
+			group:       "pokemon",
+			function:    commandBag,
+		},
+		"export": {
+			name:        "export",
+			description: "write your pokedex to a csv, markdown, html or json report",
+			usage:       "export [--format=csv|md|html|json] <path>",
+			args: []argSpec{
+				{"path", "the file to write, which must not exist yet"},
+				{"--format=name", "csv, md, html or json, guessed from the file extension when left out"},
+			},
+			examples: []string{"export pokedex.csv", "export --format=html Reports/pokedex.html"},
+			group:    "pokemon",
+			rawArgs:  true,
+			function: commandExport,
+		},
+		"sprites": {
+			name:        "sprites",
+			description: "save every sprite of a caught pokemon to " + galleryDir,
+			usage:       "sprites download <pokemon>",
+			args:        []argSpec{{"pokemon", "a pokemon from your pokedex"}},
+			examples:    []string{"sprites download pikachu"},
+			group:       "pokemon",
+			function:    commandSprites,
+		},
+		"matchup": {
+			name:        "matchup",
+			description: "show the damage multiplier of an attack",
+			usage:       "matchup <attacker-type|pokemon> <defender-type|pokemon> [--gen=N]",
+			args: []argSpec{
+				{"attacker-type|pokemon", "the attacking type, or a pokemon to try every one of its types"},
+				{"defender-type|pokemon", "the defending type, or a pokemon to use its types"},
+				{"--gen=N", "use the type chart of an older generation"},
+			},
+			examples: []string{"matchup electric water", "matchup pikachu rattata", "matchup ghost normal --gen=1"},
+			group:    "reference",
+			function: commandMatchup,
+		},
+		"weaknesses": {
+			name:        "weaknesses",
+			description: "list the multiplier of every attacking type against a pokemon",
+			usage:       "weaknesses <pokemon> [--gen=N]",
+			args: []argSpec{
+				{"pokemon", "the defending pokemon"},
+				{"--gen=N", "use the type chart of an older generation"},
+			},
+			examples: []string{"weaknesses pikachu", "weaknesses clefairy --gen=5"},
+			group:    "reference",
+			function: commandWeaknesses,
+		},
+		"search": {
+			name:        "search",
+			description: "search your pokedex",
+			usage:       "search <field:value|field<op>N>... [--sort=fields] [--limit=N] [--all] [--format=name]",
+			args: []argSpec{
+				{"field:value", "name, type, ability, move, caught, shiny, gender or nature matches"},
+				{"field<op>N", "stat.X, id, height, weight, base_exp, total or level compared with <, <=, >, >=, = or !="},
+				{"--sort=fields", "comma separated fields to sort by, - in front for descending"},
+				{"--limit=N", "show at most N results"},
+				{"--all", "also search species you looked up, evolved or traded away but have not caught"},
+				{"--format=name", "table, csv, md or json"},
+			},
+			examples: []string{"search type:fire stat.speed>100", "search weight<500 --sort=-stat.attack --limit=5"},
+			group:    "reference",
+			function: commandSearch,
+		},
+		"compare": {
+			name:        "compare",
+			description: "line up the stats of pokemon",
+			usage:       "compare <pokemon> <pokemon> [pokemon...] [--format=name]",
+			args: []argSpec{
+				{"pokemon", "two or more pokemon, caught or not"},
+				{"--format=name", "table, csv, md or json"},
+			},
+			examples: []string{"compare pikachu raichu", "compare pikachu raichu pichu --format=md"},
+			group:    "reference",
+			function: commandCompare,
+		},
+		"profile": {
+			name:        "profile",
+			description: "manage trainer profiles, each with its own pokedex, party, bag and position",
+			usage:       "profile [list|new <name>|switch <name>|delete <name>]",
+			args: []argSpec{
+				{"list", "list the profiles, * marks the active one"},
+				{"new <name>", "create a profile and switch to it"},
+				{"switch <name>", "save the active profile and load another"},
+				{"delete <name>", "delete a profile that is not active"},
+			},
+			examples: []string{"profile", "profile new ash", "profile switch misty"},
+			group:    "settings",
+			saves:    true,
+			function: commandProfile,
+		},
+		"version": {
+			name:        "version",
+			description: "show or set the game version",
+			usage:       "version [name|all]",
+			args:        []argSpec{{"name|all", "the game version explore, where, battle, learnsets and sprites are limited to, all for every game"}},
+			examples:    []string{"version", "version yellow", "version all"},
+			group:       "settings",
+			saves:       true,
+			function:    commandVersion,
+		},
+		"language": {
+			name:        "language",
+			description: "show or set the language of names and messages",
+			usage:       "language [code|none]",
+			args:        []argSpec{{"code|none", "a PokeAPI language code such as de, fr, es or ja, none for slugs"}},
+			examples:    []string{"language", "language de", "language none"},
+			group:       "settings",
+			saves:       true,
+			function:    commandLanguage,
+		},
+		"alias": {
+			name:        "alias",
+			description: "list or define your own names for commands",
+			usage:       "alias [name=command]",
+			args:        []argSpec{{"name=command", "type name instead of command, any arguments after it are kept"}},
+			examples:    []string{"alias", "alias ex=explore", "alias yex=explore --version=yellow"},
+			group:       "settings",
+			function:    commandAlias,
+		},
+		"macro": {
+			name:        "macro",
+			description: "list or define commands that run several commands",
+			usage:       "macro [name=command; command...]",
+			args:        []argSpec{{"name=command; command...", "commands separated by ;, $1..$9 are replaced by the arguments and $@ by all of them"}},
+			examples:    []string{"macro", "macro scout=travel $1; explore", "macro get=catch $1; inspect $1 --sprite=none"},
+			group:       "settings",
+			function:    commandMacro,
+		},
+		"unalias": {
+			name:        "unalias",
+			description: "remove one of your aliases or macros",
+			usage:       "unalias <name>",
+			args:        []argSpec{{"name", "the alias or macro to remove"}},
+			examples:    []string{"unalias ex"},
+			group:       "settings",
+			function:    commandUnalias,
 		},
 	}
 }
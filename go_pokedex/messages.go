@@ -0,0 +1,508 @@
+package main
+
+// messageCatalog translates the REPL's own messages and help text, keyed by
+// PokeAPI language code and then by the English message. Anything missing is
+// shown in English.
+var messageCatalog = map[string]map[string]string{
+	"de": {
+		"welcome to the pokedex!":          "Willkommen im Pokédex!",
+		"usage:":                           "Verwendung:",
+		"no such command":                  "Unbekannter Befehl",
+		"Error":                            "Fehler",
+		"threw a pokeball at %s":           "Pokéball auf %s geworfen",
+		"caught %s":                        "%s gefangen",
+		"%s escaped!":                      "%s ist entkommen!",
+		"%s is shiny!":                     "%s schillert!",
+		"%s joined your party":             "%s ist deinem Team beigetreten",
+		"already registered in pokedex":    "bereits im Pokédex registriert",
+		"you have not caught that pokemon": "dieses Pokémon hast du nicht gefangen",
+		"Your PokeDex:":                    "Dein Pokédex:",
+		"You have not caught any pokemon yet, catch some with the catch command": "Du hast noch keine Pokémon gefangen, fang welche mit dem Befehl catch",
+		"Your party:": "Dein Team:",
+		"Your party is empty, catch some pokemon with the catch command": "Dein Team ist leer, fang Pokémon mit dem Befehl catch",
+		"%s now leads your party": "%s führt jetzt dein Team an",
+		"Your bag:":               "Dein Beutel:",
+		"Your bag is empty, wild pokemon sometimes drop the items they hold": "Dein Beutel ist leer, wilde Pokémon lassen manchmal ihre Items fallen",
+		"No previous pages to go back to.":                                   "Keine vorherige Seite vorhanden.",
+		"current language: %s":                                               "aktuelle Sprache: %s",
+		"language set to %s":                                                 "Sprache auf %s gesetzt",
+		"no language set, showing names as PokeAPI slugs":                    "keine Sprache gesetzt, Namen werden als PokeAPI-Slugs angezeigt",
+		"show help on commands":                                              "Hilfe zu den Befehlen anzeigen",
+		"exit the program":                                                   "das Programm beenden",
+		"list locations":                                                     "Orte auflisten",
+		"go back a page when seeing location":                                "eine Seite der Orte zurückblättern",
+		"catch a specific pokemon at a chance to add to the pokedex":         "ein Pokémon mit etwas Glück fangen und in den Pokédex aufnehmen",
+		"list the whole caught pokedex":                                      "den ganzen Pokédex auflisten",
+		"list the regions":                                                   "die Regionen auflisten",
+		"list the items in your bag":                                         "die Items in deinem Beutel auflisten",
+		"show the evolution chain of a pokemon as a tree":                    "die Entwicklungsreihe eines Pokémon als Baum anzeigen",
+		"arguments:":                                                         "Argumente:",
+		"examples:":                                                          "Beispiele:",
+		"aliases:":                                                           "Aliase:",
+		"type help <command> or <command> --help for its arguments and examples": "help <Befehl> oder <Befehl> --help zeigt Argumente und Beispiele",
+		"world":                          "Welt",
+		"pokemon":                        "Pokémon",
+		"reference":                      "Nachschlagen",
+		"settings":                       "Einstellungen",
+		"general":                        "Allgemein",
+		"list the pokemon in an area":    "die Pokémon eines Gebiets auflisten",
+		"list the locations of a region": "die Orte einer Region auflisten",
+		"list the areas of a location":   "die Gebiete eines Ortes auflisten",
+		"move to an area, explore and battle then use it":               "in ein Gebiet reisen, explore und battle nutzen es dann",
+		"list the areas a pokemon can be found in":                      "die Gebiete auflisten, in denen ein Pokémon vorkommt",
+		"show the stats and sprite of a caught pokemon":                 "Werte und Bild eines gefangenen Pokémon anzeigen",
+		"list your party or pick its lead":                              "dein Team auflisten oder seinen Anführer wählen",
+		"battle a wild pokemon with your lead":                          "mit deinem Anführer gegen ein wildes Pokémon kämpfen",
+		"use an item from your bag on a pokemon":                        "ein Item aus deinem Beutel bei einem Pokémon einsetzen",
+		"show the damage multiplier of an attack":                       "den Schadensmultiplikator einer Attacke anzeigen",
+		"list the multiplier of every attacking type against a pokemon": "den Multiplikator jedes angreifenden Typs gegen ein Pokémon auflisten",
+		"search your pokedex":                                           "deinen Pokédex durchsuchen",
+		"line up the stats of pokemon":                                  "die Werte von Pokémon vergleichen",
+		"show or set the game version":                                  "die Spielversion anzeigen oder setzen",
+		"show or set the language of names and messages":                "die Sprache von Namen und Meldungen anzeigen oder setzen",
+		"%s gained %d exp":                                              "%s erhält %d E.-Punkte",
+		"%s grew to level %d!":                                          "%s erreicht Level %d!",
+		"%s learned %s!":                                                "%s erlernt %s!",
+		"%s wants to learn %s, but already knows four moves":            "%s möchte %s erlernen, kennt aber schon vier Attacken",
+		"forget which move? (1-4, or n to give up) > ":                  "welche Attacke vergessen? (1-4, oder n zum Abbrechen) > ",
+		"%s did not learn %s":                                           "%s hat %s nicht erlernt",
+		"%s forgot %s and learned %s!":                                  "%s hat %s vergessen und %s erlernt!",
+		"%s could evolve into %s, but you already have one":             "%s könnte sich zu %s entwickeln, aber du hast schon eins",
+		"what? %s is evolving!":                                         "Nanu? %s entwickelt sich!",
+		"%s evolved into %s!":                                           "%s hat sich zu %s entwickelt!",
+		"%s was registered in the pokedex":                              "%s wurde im Pokédex registriert",
+		"it had no effect":                                              "es hatte keine Wirkung",
+		"no effect":                                                     "keine Wirkung",
+		"super effective":                                               "sehr effektiv",
+		"not very effective":                                            "nicht sehr effektiv",
+		"normal damage":                                                 "normaler Schaden",
+		"%s has no areas with wild pokemon":                             "%s hat keine Gebiete mit wilden Pokémon",
+		"saved %d sprites to %s":                                        "%d Bilder in %s gespeichert",
+		", did you mean %s?":                                            ", meintest du %s?",
+		", and there is no pokemon named %s":                            ", und es gibt kein Pokémon namens %s",
+		"%s won the battle!":                                            "%s hat den Kampf gewonnen!",
+		"the wild %s dropped a %s":                                      "das wilde %s hat %s fallen gelassen",
+		"(evolved)":                                                     "(entwickelt)",
+		"the asset cache holds %.1f of %d MiB":                          "der Bild-Cache belegt %.1f von %d MiB",
+		"(caught)":                                                      "(gefangen)",
+		"(hidden)":                                                      "(versteckt)",
+		"height":                                                        "Größe",
+		"weight":                                                        "Gewicht",
+		"total":                                                         "Summe",
+		"types":                                                         "Typen",
+		"abilities":                                                     "Fähigkeiten",
+		"wild %s":                                                       "wildes %s",
+		"%s used %s!":                                                   "%s setzt %s ein!",
+		"but it missed!":                                                "aber es ging daneben!",
+		"it doesn't affect %s...":                                       "es hat keine Wirkung auf %s...",
+		"a critical hit!":                                               "ein Volltreffer!",
+		"it's super effective!":                                         "das ist sehr effektiv!",
+		"it's not very effective...":                                    "das ist nicht sehr effektiv...",
+		"%s took %d damage":                                             "%s erleidet %d Schaden",
+		"power %d":                                                      "Stärke %d",
+		"run":                                                           "fliehen",
+		"move > ":                                                       "Attacke > ",
+		"pick a move number or r to run":                                "wähle die Nummer einer Attacke oder r zum Fliehen",
+		"a wild %s (Lv%d) appeared!":                                    "ein wildes %s (Lv%d) erscheint!",
+		"go, %s!":                                                       "los, %s!",
+		"turn %d":                                                       "Runde %d",
+		"got away safely!":                                              "du bist entkommen!",
+		"couldn't escape!":                                              "Flucht gescheitert!",
+		"%s fainted!":                                                   "%s wurde besiegt!",
+		"unable to load your aliases:":                                  "deine Aliase konnten nicht geladen werden:",
+		"unable to save your profile:":                                  "dein Profil konnte nicht gespeichert werden:",
+		"No records for %s":                                             "Keine Einträge für %s",
+		"%s is a level %d %s":                                           "%s ist Level %d, %s",
+		"male":                                                          "männlich",
+		"female":                                                        "weiblich",
+		"genderless":                                                    "geschlechtslos",
+		"no sprite: %v":                                                 "kein Sprite: %v",
+		"Caught %d pokemon (%d male, %d female, %d genderless), %d shiny": "%d Pokémon gefangen (%d männlich, %d weiblich, %d geschlechtslos), %d schillernd",
+		"no pokemon found":                    "keine Pokémon gefunden",
+		"encounter rate: %s":                  "Begegnungsrate: %s",
+		"level":                               "Level",
+		"chance":                              "Chance",
+		"conditions":                          "Bedingungen",
+		"versions":                            "Editionen",
+		"you have not travelled anywhere yet": "du bist noch nirgendwohin gereist",
+		"you are at %s":                       "du bist in %s",
+		"you travelled to %s (%s)":            "du bist nach %s (%s) gereist",
+		"no game version set, showing data from every game":                 "keine Edition gesetzt, es werden Daten aller Spiele angezeigt",
+		"current game version: %s (%s)":                                     "aktuelle Edition: %s (%s)",
+		"showing data from every game":                                      "Daten aller Spiele werden angezeigt",
+		"current game version set to %s (%s)":                               "Edition auf %s (%s) gesetzt",
+		"%s can't be found in the wild in %s":                               "%s kommt in %s nicht in freier Wildbahn vor",
+		"%s can't be found in the wild":                                     "%s kommt nicht in freier Wildbahn vor",
+		"explore which area? (number, or enter to skip) > ":                 "welches Gebiet erkunden? (Nummer, oder Enter zum Überspringen) > ",
+		"connected to %s":                                                   "mit %s verbunden",
+		"offer which pokemon? (empty to cancel) > ":                         "welches Pokémon anbieten? (leer zum Abbrechen) > ",
+		"waiting for %s's offer...":                                         "warte auf das Angebot von %s...",
+		"%s offers %s, but you already have one":                            "%s bietet %s an, aber du hast schon eins",
+		"%s offers %s, a level %d %s %s":                                    "%s bietet %s an, Level %d, %s, %s",
+		"trade your %s for it? (y/n) > ":                                    "dein %s dafür tauschen? (y/n) > ",
+		"settled %s's interrupted trade, you can trade again now":           "der unterbrochene Tausch von %s ist geklärt, du kannst wieder tauschen",
+		"finishing the trade with %s that was interrupted":                  "der unterbrochene Tausch mit %s wird abgeschlossen",
+		"the interrupted trade with %s never went through, nothing changed": "der unterbrochene Tausch mit %s kam nie zustande, nichts hat sich geändert",
+		"you sent %s to %s and received %s!":                                "du hast %s an %s geschickt und %s erhalten!",
+		"no trades yet":                                                     "noch keine Tausche",
+		"gave %s to %s for %s":                                              "%s an %s gegen %s getauscht",
+		"no interrupted trade to give up":                                   "kein unterbrochener Tausch zum Aufgeben",
+		"give up the interrupted trade with %s? if they finished it, both of you keep %s (y/n) > ": "den unterbrochenen Tausch mit %s aufgeben? hat die andere Seite ihn abgeschlossen, behaltet ihr beide %s (y/n) > ",
+		"waiting for a trainer on %s...":                                                           "warte auf einen Trainer an %s...",
+		"%s left your box and was written to %s":                                                   "%s hat deine Box verlassen und wurde nach %s geschrieben",
+		"%s is signed by key %s, but earlier files from %s were signed by %s":                      "%s ist mit Schlüssel %s signiert, frühere Dateien von %s aber mit %s",
+		"import it anyway? (y/n) > ":                                                               "trotzdem importieren? (y/n) > ",
+		"%s from %s (key %s) joined your box":                                                      "%s von %s (Schlüssel %s) ist jetzt in deiner Box",
+		"no profiles yet, create one with profile new <name>":                                      "noch keine Profile, erstelle eins mit profile new <name>",
+		"your progress so far is kept in %s":                                                       "dein bisheriger Fortschritt wird in %s gespeichert",
+		"created profile %s":                                                                       "Profil %s erstellt",
+		"your progress so far is not in a profile and will be lost, switch to %s anyway? (y/n) > ": "dein bisheriger Fortschritt ist in keinem Profil und geht verloren, trotzdem zu %s wechseln? (y/n) > ",
+		"stayed, keep your progress with profile new <name>":                                       "geblieben, sichere deinen Fortschritt mit profile new <name>",
+		"switched to %s, %d pokemon caught":                                                        "zu %s gewechselt, %d Pokémon gefangen",
+		"delete %s and every pokemon in it? (y/n) > ":                                              "%s und alle Pokémon darin löschen? (y/n) > ",
+		"kept %s":                "%s behalten",
+		"deleted profile %s":     "Profil %s gelöscht",
+		"no aliases defined":     "keine Aliase definiert",
+		"no macros defined":      "keine Makros definiert",
+		"caught":                 "gefangen",
+		"skipping %s: %v":        "%s übersprungen: %v",
+		"no sprite for %s: %v":   "kein Sprite für %s: %v",
+		"wrote %d pokemon to %s": "%d Pokémon nach %s geschrieben",
+		"no pokemon match":       "keine Pokémon passen",
+		"your trainer key, give it to whoever exports a pokemon for you: %s": "dein Trainerschlüssel, gib ihn jedem, der dir ein Pokémon exportiert: %s",
+	},
+	"fr": {
+		"welcome to the pokedex!":          "bienvenue dans le Pokédex !",
+		"usage:":                           "utilisation :",
+		"no such command":                  "commande inconnue",
+		"Error":                            "Erreur",
+		"threw a pokeball at %s":           "Poké Ball lancée sur %s",
+		"caught %s":                        "%s attrapé",
+		"%s escaped!":                      "%s s'est échappé !",
+		"%s is shiny!":                     "%s est chromatique !",
+		"%s joined your party":             "%s rejoint votre équipe",
+		"already registered in pokedex":    "déjà enregistré dans le Pokédex",
+		"you have not caught that pokemon": "vous n'avez pas attrapé ce Pokémon",
+		"Your PokeDex:":                    "Votre Pokédex :",
+		"You have not caught any pokemon yet, catch some with the catch command": "Vous n'avez encore attrapé aucun Pokémon, utilisez la commande catch",
+		"Your party:": "Votre équipe :",
+		"Your party is empty, catch some pokemon with the catch command": "Votre équipe est vide, attrapez des Pokémon avec la commande catch",
+		"%s now leads your party": "%s mène maintenant votre équipe",
+		"Your bag:":               "Votre sac :",
+		"Your bag is empty, wild pokemon sometimes drop the items they hold": "Votre sac est vide, les Pokémon sauvages laissent parfois tomber leurs objets",
+		"No previous pages to go back to.":                                   "Aucune page précédente.",
+		"current language: %s":                                               "langue actuelle : %s",
+		"language set to %s":                                                 "langue réglée sur %s",
+		"no language set, showing names as PokeAPI slugs":                    "aucune langue choisie, les noms sont affichés tels que dans PokeAPI",
+		"show help on commands":                                              "afficher l'aide des commandes",
+		"exit the program":                                                   "quitter le programme",
+		"list locations":                                                     "lister les lieux",
+		"go back a page when seeing location":                                "revenir à la page précédente des lieux",
+		"catch a specific pokemon at a chance to add to the pokedex":         "tenter d'attraper un Pokémon pour l'ajouter au Pokédex",
+		"list the whole caught pokedex":                                      "lister tout le Pokédex",
+		"list the regions":                                                   "lister les régions",
+		"list the items in your bag":                                         "lister les objets de votre sac",
+		"show the evolution chain of a pokemon as a tree":                    "afficher la chaîne d'évolution d'un Pokémon sous forme d'arbre",
+		"arguments:":                                                         "arguments :",
+		"examples:":                                                          "exemples :",
+		"aliases:":                                                           "alias :",
+		"type help <command> or <command> --help for its arguments and examples": "tapez help <commande> ou <commande> --help pour ses arguments et exemples",
+		"world":                          "monde",
+		"pokemon":                        "Pokémon",
+		"reference":                      "référence",
+		"settings":                       "réglages",
+		"general":                        "général",
+		"list the pokemon in an area":    "lister les Pokémon d'une zone",
+		"list the locations of a region": "lister les lieux d'une région",
+		"list the areas of a location":   "lister les zones d'un lieu",
+		"move to an area, explore and battle then use it":               "aller dans une zone, utilisée ensuite par explore et battle",
+		"list the areas a pokemon can be found in":                      "lister les zones où trouver un Pokémon",
+		"show the stats and sprite of a caught pokemon":                 "afficher les statistiques et l'image d'un Pokémon attrapé",
+		"list your party or pick its lead":                              "lister votre équipe ou choisir son meneur",
+		"battle a wild pokemon with your lead":                          "combattre un Pokémon sauvage avec votre meneur",
+		"use an item from your bag on a pokemon":                        "utiliser un objet de votre sac sur un Pokémon",
+		"show the damage multiplier of an attack":                       "afficher le multiplicateur de dégâts d'une attaque",
+		"list the multiplier of every attacking type against a pokemon": "lister le multiplicateur de chaque type offensif contre un Pokémon",
+		"search your pokedex":                                           "chercher dans votre Pokédex",
+		"line up the stats of pokemon":                                  "comparer les statistiques de Pokémon",
+		"show or set the game version":                                  "afficher ou choisir la version du jeu",
+		"show or set the language of names and messages":                "afficher ou choisir la langue des noms et des messages",
+		"%s gained %d exp":                                              "%s gagne %d points d'expérience",
+		"%s grew to level %d!":                                          "%s monte au niveau %d !",
+		"%s learned %s!":                                                "%s apprend %s !",
+		"%s wants to learn %s, but already knows four moves":            "%s veut apprendre %s, mais connaît déjà quatre capacités",
+		"forget which move? (1-4, or n to give up) > ":                  "oublier quelle capacité ? (1-4, ou n pour abandonner) > ",
+		"%s did not learn %s":                                           "%s n'a pas appris %s",
+		"%s forgot %s and learned %s!":                                  "%s a oublié %s et appris %s !",
+		"%s could evolve into %s, but you already have one":             "%s pourrait évoluer en %s, mais vous en avez déjà un",
+		"what? %s is evolving!":                                         "Quoi ? %s évolue !",
+		"%s evolved into %s!":                                           "%s a évolué en %s !",
+		"%s was registered in the pokedex":                              "%s a été enregistré dans le Pokédex",
+		"it had no effect":                                              "cela n'a eu aucun effet",
+		"no effect":                                                     "aucun effet",
+		"super effective":                                               "super efficace",
+		"not very effective":                                            "pas très efficace",
+		"normal damage":                                                 "dégâts normaux",
+		"%s has no areas with wild pokemon":                             "%s n'a aucune zone avec des Pokémon sauvages",
+		"saved %d sprites to %s":                                        "%d images enregistrées dans %s",
+		", did you mean %s?":                                            ", vouliez-vous dire %s ?",
+		", and there is no pokemon named %s":                            ", et aucun Pokémon ne s'appelle %s",
+		"%s won the battle!":                                            "%s a gagné le combat !",
+		"the wild %s dropped a %s":                                      "le %s sauvage a laissé tomber : %s",
+		"(evolved)":                                                     "(évolué)",
+		"the asset cache holds %.1f of %d MiB":                          "le cache d'images occupe %.1f Mio sur %d",
+		"(caught)":                                                      "(capturé)",
+		"(hidden)":                                                      "(caché)",
+		"height":                                                        "taille",
+		"weight":                                                        "poids",
+		"total":                                                         "total",
+		"types":                                                         "types",
+		"abilities":                                                     "talents",
+		"wild %s":                                                       "%s sauvage",
+		"%s used %s!":                                                   "%s utilise %s !",
+		"but it missed!":                                                "mais il a raté !",
+		"it doesn't affect %s...":                                       "ça n'affecte pas %s...",
+		"a critical hit!":                                               "coup critique !",
+		"it's super effective!":                                         "c'est super efficace !",
+		"it's not very effective...":                                    "ce n'est pas très efficace...",
+		"%s took %d damage":                                             "%s subit %d dégâts",
+		"power %d":                                                      "puissance %d",
+		"run":                                                           "fuir",
+		"move > ":                                                       "capacité > ",
+		"pick a move number or r to run":                                "choisis le numéro d'une capacité ou r pour fuir",
+		"a wild %s (Lv%d) appeared!":                                    "un %s sauvage (N%d) apparaît !",
+		"go, %s!":                                                       "vas-y, %s !",
+		"turn %d":                                                       "tour %d",
+		"got away safely!":                                              "tu as pris la fuite !",
+		"couldn't escape!":                                              "impossible de fuir !",
+		"%s fainted!":                                                   "%s est K.O. !",
+		"unable to load your aliases:":                                  "impossible de charger tes alias :",
+		"unable to save your profile:":                                  "impossible d'enregistrer ton profil :",
+		"No records for %s":                                             "Aucune donnée pour %s",
+		"%s is a level %d %s":                                           "%s est niveau %d, %s",
+		"male":                                                          "mâle",
+		"female":                                                        "femelle",
+		"genderless":                                                    "asexué",
+		"no sprite: %v":                                                 "pas de sprite : %v",
+		"Caught %d pokemon (%d male, %d female, %d genderless), %d shiny": "%d Pokémon capturés (%d mâles, %d femelles, %d asexués), %d chromatiques",
+		"no pokemon found":                    "aucun Pokémon trouvé",
+		"encounter rate: %s":                  "taux de rencontre : %s",
+		"level":                               "niveau",
+		"chance":                              "chance",
+		"conditions":                          "conditions",
+		"versions":                            "versions",
+		"you have not travelled anywhere yet": "tu n'as encore voyagé nulle part",
+		"you are at %s":                       "tu es à %s",
+		"you travelled to %s (%s)":            "tu as voyagé jusqu'à %s (%s)",
+		"no game version set, showing data from every game":                 "aucune version de jeu choisie, les données de tous les jeux sont affichées",
+		"current game version: %s (%s)":                                     "version de jeu actuelle : %s (%s)",
+		"showing data from every game":                                      "les données de tous les jeux sont affichées",
+		"current game version set to %s (%s)":                               "version de jeu réglée sur %s (%s)",
+		"%s can't be found in the wild in %s":                               "%s ne se trouve pas à l'état sauvage dans %s",
+		"%s can't be found in the wild":                                     "%s ne se trouve pas à l'état sauvage",
+		"explore which area? (number, or enter to skip) > ":                 "explorer quelle zone ? (numéro, ou Entrée pour passer) > ",
+		"connected to %s":                                                   "connecté à %s",
+		"offer which pokemon? (empty to cancel) > ":                         "proposer quel Pokémon ? (vide pour annuler) > ",
+		"waiting for %s's offer...":                                         "en attente de l'offre de %s...",
+		"%s offers %s, but you already have one":                            "%s propose %s, mais tu en as déjà un",
+		"%s offers %s, a level %d %s %s":                                    "%s propose %s, niveau %d, %s, %s",
+		"trade your %s for it? (y/n) > ":                                    "échanger ton %s contre lui ? (y/n) > ",
+		"settled %s's interrupted trade, you can trade again now":           "l'échange interrompu de %s est réglé, tu peux de nouveau échanger",
+		"finishing the trade with %s that was interrupted":                  "fin de l'échange interrompu avec %s",
+		"the interrupted trade with %s never went through, nothing changed": "l'échange interrompu avec %s n'a jamais abouti, rien n'a changé",
+		"you sent %s to %s and received %s!":                                "tu as envoyé %s à %s et reçu %s !",
+		"no trades yet":                                                     "aucun échange pour l'instant",
+		"gave %s to %s for %s":                                              "%s donné à %s contre %s",
+		"no interrupted trade to give up":                                   "aucun échange interrompu à abandonner",
+		"give up the interrupted trade with %s? if they finished it, both of you keep %s (y/n) > ": "abandonner l'échange interrompu avec %s ? s'il a été terminé de l'autre côté, vous gardez tous les deux %s (y/n) > ",
+		"waiting for a trainer on %s...":                                                           "en attente d'un dresseur sur %s...",
+		"%s left your box and was written to %s":                                                   "%s a quitté ta boîte et a été écrit dans %s",
+		"%s is signed by key %s, but earlier files from %s were signed by %s":                      "%s est signé par la clé %s, mais les fichiers précédents de %s étaient signés par %s",
+		"import it anyway? (y/n) > ":                                                               "l'importer quand même ? (y/n) > ",
+		"%s from %s (key %s) joined your box":                                                      "%s de %s (clé %s) a rejoint ta boîte",
+		"no profiles yet, create one with profile new <name>":                                      "aucun profil pour l'instant, crées-en un avec profile new <name>",
+		"your progress so far is kept in %s":                                                       "ta progression jusqu'ici est gardée dans %s",
+		"created profile %s":                                                                       "profil %s créé",
+		"your progress so far is not in a profile and will be lost, switch to %s anyway? (y/n) > ": "ta progression n'est dans aucun profil et sera perdue, passer à %s quand même ? (y/n) > ",
+		"stayed, keep your progress with profile new <name>":                                       "rien n'a changé, garde ta progression avec profile new <name>",
+		"switched to %s, %d pokemon caught":                                                        "passé à %s, %d Pokémon capturés",
+		"delete %s and every pokemon in it? (y/n) > ":                                              "supprimer %s et tous ses Pokémon ? (y/n) > ",
+		"kept %s":                "%s conservé",
+		"deleted profile %s":     "profil %s supprimé",
+		"no aliases defined":     "aucun alias défini",
+		"no macros defined":      "aucune macro définie",
+		"caught":                 "capturé",
+		"skipping %s: %v":        "%s ignoré : %v",
+		"no sprite for %s: %v":   "pas de sprite pour %s : %v",
+		"wrote %d pokemon to %s": "%d Pokémon écrits dans %s",
+		"no pokemon match":       "aucun Pokémon ne correspond",
+		"your trainer key, give it to whoever exports a pokemon for you: %s": "ta clé de dresseur, donne-la à qui t'exporte un Pokémon : %s",
+	},
+	"es": {
+		"welcome to the pokedex!":          "¡bienvenido a la Pokédex!",
+		"usage:":                           "uso:",
+		"no such command":                  "no existe ese comando",
+		"Error":                            "Error",
+		"threw a pokeball at %s":           "lanzaste una Poké Ball a %s",
+		"caught %s":                        "atrapaste a %s",
+		"%s escaped!":                      "¡%s escapó!",
+		"%s is shiny!":                     "¡%s es variocolor!",
+		"%s joined your party":             "%s se unió a tu equipo",
+		"already registered in pokedex":    "ya registrado en la Pokédex",
+		"you have not caught that pokemon": "no has atrapado ese Pokémon",
+		"Your PokeDex:":                    "Tu Pokédex:",
+		"You have not caught any pokemon yet, catch some with the catch command": "Todavía no has atrapado ningún Pokémon, usa el comando catch",
+		"Your party:": "Tu equipo:",
+		"Your party is empty, catch some pokemon with the catch command": "Tu equipo está vacío, atrapa Pokémon con el comando catch",
+		"%s now leads your party": "%s ahora encabeza tu equipo",
+		"Your bag:":               "Tu mochila:",
+		"Your bag is empty, wild pokemon sometimes drop the items they hold": "Tu mochila está vacía, los Pokémon salvajes a veces sueltan sus objetos",
+		"No previous pages to go back to.":                                   "No hay páginas anteriores.",
+		"current language: %s":                                               "idioma actual: %s",
+		"language set to %s":                                                 "idioma cambiado a %s",
+		"no language set, showing names as PokeAPI slugs":                    "sin idioma, los nombres se muestran como en PokeAPI",
+		"show help on commands":                                              "mostrar la ayuda de los comandos",
+		"exit the program":                                                   "salir del programa",
+		"list locations":                                                     "listar ubicaciones",
+		"go back a page when seeing location":                                "volver a la página anterior de ubicaciones",
+		"catch a specific pokemon at a chance to add to the pokedex":         "intentar atrapar un Pokémon para añadirlo a la Pokédex",
+		"list the whole caught pokedex":                                      "listar toda la Pokédex",
+		"list the regions":                                                   "listar las regiones",
+		"list the items in your bag":                                         "listar los objetos de tu mochila",
+		"show the evolution chain of a pokemon as a tree":                    "mostrar la cadena evolutiva de un Pokémon como árbol",
+		"arguments:":                                                         "argumentos:",
+		"examples:":                                                          "ejemplos:",
+		"aliases:":                                                           "alias:",
+		"type help <command> or <command> --help for its arguments and examples": "escribe help <comando> o <comando> --help para ver sus argumentos y ejemplos",
+		"world":                          "mundo",
+		"pokemon":                        "Pokémon",
+		"reference":                      "consulta",
+		"settings":                       "ajustes",
+		"general":                        "general",
+		"list the pokemon in an area":    "listar los Pokémon de una zona",
+		"list the locations of a region": "listar las ubicaciones de una región",
+		"list the areas of a location":   "listar las zonas de una ubicación",
+		"move to an area, explore and battle then use it":               "viajar a una zona, que luego usan explore y battle",
+		"list the areas a pokemon can be found in":                      "listar las zonas donde aparece un Pokémon",
+		"show the stats and sprite of a caught pokemon":                 "mostrar las estadísticas y la imagen de un Pokémon atrapado",
+		"list your party or pick its lead":                              "listar tu equipo o elegir quién lo encabeza",
+		"battle a wild pokemon with your lead":                          "combatir contra un Pokémon salvaje con tu primer Pokémon",
+		"use an item from your bag on a pokemon":                        "usar un objeto de tu mochila en un Pokémon",
+		"show the damage multiplier of an attack":                       "mostrar el multiplicador de daño de un ataque",
+		"list the multiplier of every attacking type against a pokemon": "listar el multiplicador de cada tipo atacante contra un Pokémon",
+		"search your pokedex":                                           "buscar en tu Pokédex",
+		"line up the stats of pokemon":                                  "comparar las estadísticas de varios Pokémon",
+		"show or set the game version":                                  "mostrar o cambiar la versión del juego",
+		"show or set the language of names and messages":                "mostrar o cambiar el idioma de nombres y mensajes",
+		"%s gained %d exp":                                              "%s ganó %d puntos de experiencia",
+		"%s grew to level %d!":                                          "¡%s subió al nivel %d!",
+		"%s learned %s!":                                                "¡%s aprendió %s!",
+		"%s wants to learn %s, but already knows four moves":            "%s quiere aprender %s, pero ya conoce cuatro movimientos",
+		"forget which move? (1-4, or n to give up) > ":                  "¿qué movimiento olvidar? (1-4, o n para desistir) > ",
+		"%s did not learn %s":                                           "%s no aprendió %s",
+		"%s forgot %s and learned %s!":                                  "¡%s olvidó %s y aprendió %s!",
+		"%s could evolve into %s, but you already have one":             "%s podría evolucionar a %s, pero ya tienes uno",
+		"what? %s is evolving!":                                         "¿Qué? ¡%s está evolucionando!",
+		"%s evolved into %s!":                                           "¡%s evolucionó a %s!",
+		"%s was registered in the pokedex":                              "%s quedó registrado en la Pokédex",
+		"it had no effect":                                              "no tuvo ningún efecto",
+		"no effect":                                                     "sin efecto",
+		"super effective":                                               "súper eficaz",
+		"not very effective":                                            "poco eficaz",
+		"normal damage":                                                 "daño normal",
+		"%s has no areas with wild pokemon":                             "%s no tiene zonas con Pokémon salvajes",
+		"saved %d sprites to %s":                                        "%d imágenes guardadas en %s",
+		", did you mean %s?":                                            ", ¿quisiste decir %s?",
+		", and there is no pokemon named %s":                            ", y no existe ningún Pokémon llamado %s",
+		"%s won the battle!":                                            "¡%s ganó el combate!",
+		"the wild %s dropped a %s":                                      "el %s salvaje soltó: %s",
+		"(evolved)":                                                     "(evolucionado)",
+		"the asset cache holds %.1f of %d MiB":                          "la caché de imágenes ocupa %.1f de %d MiB",
+		"(caught)":                                                      "(capturado)",
+		"(hidden)":                                                      "(oculta)",
+		"height":                                                        "altura",
+		"weight":                                                        "peso",
+		"total":                                                         "total",
+		"types":                                                         "tipos",
+		"abilities":                                                     "habilidades",
+		"wild %s":                                                       "%s salvaje",
+		"%s used %s!":                                                   "¡%s usó %s!",
+		"but it missed!":                                                "¡pero falló!",
+		"it doesn't affect %s...":                                       "no afecta a %s...",
+		"a critical hit!":                                               "¡un golpe crítico!",
+		"it's super effective!":                                         "¡es muy eficaz!",
+		"it's not very effective...":                                    "no es muy eficaz...",
+		"%s took %d damage":                                             "%s recibió %d de daño",
+		"power %d":                                                      "potencia %d",
+		"run":                                                           "huir",
+		"move > ":                                                       "movimiento > ",
+		"pick a move number or r to run":                                "elige el número de un movimiento o r para huir",
+		"a wild %s (Lv%d) appeared!":                                    "¡apareció un %s salvaje (Nv%d)!",
+		"go, %s!":                                                       "¡adelante, %s!",
+		"turn %d":                                                       "turno %d",
+		"got away safely!":                                              "¡escapaste sin problemas!",
+		"couldn't escape!":                                              "¡no pudiste escapar!",
+		"%s fainted!":                                                   "¡%s se debilitó!",
+		"unable to load your aliases:":                                  "no se pudieron cargar tus alias:",
+		"unable to save your profile:":                                  "no se pudo guardar tu perfil:",
+		"No records for %s":                                             "No hay registros de %s",
+		"%s is a level %d %s":                                           "%s es de nivel %d, %s",
+		"male":                                                          "macho",
+		"female":                                                        "hembra",
+		"genderless":                                                    "sin género",
+		"no sprite: %v":                                                 "sin sprite: %v",
+		"Caught %d pokemon (%d male, %d female, %d genderless), %d shiny": "%d Pokémon capturados (%d machos, %d hembras, %d sin género), %d variocolor",
+		"no pokemon found":                    "no se encontraron Pokémon",
+		"encounter rate: %s":                  "tasa de encuentro: %s",
+		"level":                               "nivel",
+		"chance":                              "probabilidad",
+		"conditions":                          "condiciones",
+		"versions":                            "ediciones",
+		"you have not travelled anywhere yet": "todavía no has viajado a ningún sitio",
+		"you are at %s":                       "estás en %s",
+		"you travelled to %s (%s)":            "viajaste a %s (%s)",
+		"no game version set, showing data from every game":                 "no hay edición elegida, se muestran datos de todos los juegos",
+		"current game version: %s (%s)":                                     "edición actual: %s (%s)",
+		"showing data from every game":                                      "se muestran datos de todos los juegos",
+		"current game version set to %s (%s)":                               "edición cambiada a %s (%s)",
+		"%s can't be found in the wild in %s":                               "%s no aparece en estado salvaje en %s",
+		"%s can't be found in the wild":                                     "%s no aparece en estado salvaje",
+		"explore which area? (number, or enter to skip) > ":                 "¿qué zona explorar? (número, o Intro para omitir) > ",
+		"connected to %s":                                                   "conectado con %s",
+		"offer which pokemon? (empty to cancel) > ":                         "¿qué Pokémon ofreces? (vacío para cancelar) > ",
+		"waiting for %s's offer...":                                         "esperando la oferta de %s...",
+		"%s offers %s, but you already have one":                            "%s ofrece %s, pero ya tienes uno",
+		"%s offers %s, a level %d %s %s":                                    "%s ofrece %s, nivel %d, %s, %s",
+		"trade your %s for it? (y/n) > ":                                    "¿cambiar tu %s por él? (y/n) > ",
+		"settled %s's interrupted trade, you can trade again now":           "el intercambio interrumpido de %s está resuelto, ya puedes volver a intercambiar",
+		"finishing the trade with %s that was interrupted":                  "terminando el intercambio interrumpido con %s",
+		"the interrupted trade with %s never went through, nothing changed": "el intercambio interrumpido con %s nunca se completó, no ha cambiado nada",
+		"you sent %s to %s and received %s!":                                "¡enviaste %s a %s y recibiste %s!",
+		"no trades yet":                                                     "todavía no hay intercambios",
+		"gave %s to %s for %s":                                              "%s dado a %s a cambio de %s",
+		"no interrupted trade to give up":                                   "no hay ningún intercambio interrumpido que abandonar",
+		"give up the interrupted trade with %s? if they finished it, both of you keep %s (y/n) > ": "¿abandonar el intercambio interrumpido con %s? si la otra parte lo terminó, ambos os quedáis con %s (y/n) > ",
+		"waiting for a trainer on %s...":                                                           "esperando a un entrenador en %s...",
+		"%s left your box and was written to %s":                                                   "%s salió de tu caja y se escribió en %s",
+		"%s is signed by key %s, but earlier files from %s were signed by %s":                      "%s está firmado con la clave %s, pero los archivos anteriores de %s se firmaron con %s",
+		"import it anyway? (y/n) > ":                                                               "¿importarlo de todos modos? (y/n) > ",
+		"%s from %s (key %s) joined your box":                                                      "%s de %s (clave %s) se unió a tu caja",
+		"no profiles yet, create one with profile new <name>":                                      "todavía no hay perfiles, crea uno con profile new <name>",
+		"your progress so far is kept in %s":                                                       "tu progreso hasta ahora se guarda en %s",
+		"created profile %s":                                                                       "perfil %s creado",
+		"your progress so far is not in a profile and will be lost, switch to %s anyway? (y/n) > ": "tu progreso no está en ningún perfil y se perderá, ¿cambiar a %s de todos modos? (y/n) > ",
+		"stayed, keep your progress with profile new <name>":                                       "te quedas, guarda tu progreso con profile new <name>",
+		"switched to %s, %d pokemon caught":                                                        "cambiado a %s, %d Pokémon capturados",
+		"delete %s and every pokemon in it? (y/n) > ":                                              "¿borrar %s y todos sus Pokémon? (y/n) > ",
+		"kept %s":                "%s conservado",
+		"deleted profile %s":     "perfil %s borrado",
+		"no aliases defined":     "no hay alias definidos",
+		"no macros defined":      "no hay macros definidas",
+		"caught":                 "capturado",
+		"skipping %s: %v":        "se omite %s: %v",
+		"no sprite for %s: %v":   "sin sprite para %s: %v",
+		"wrote %d pokemon to %s": "%d Pokémon escritos en %s",
+		"no pokemon match":       "ningún Pokémon coincide",
+		"your trainer key, give it to whoever exports a pokemon for you: %s": "tu clave de entrenador, dásela a quien te exporte un Pokémon: %s",
+	},
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestCatalogCoversMessages checks that every literal message passed to tr
+// has a translation in each catalog language.
+func TestCatalogCoversMessages(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := make(map[string]token.Position)
+	for _, file := range pkgs["main"].Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return true
+			}
+			name := ""
+			switch fun := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				name = fun.Sel.Name
+			case *ast.Ident:
+				name = fun.Name
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if name != "tr" || !ok || lit.Kind != token.STRING {
+				return true
+			}
+			msg, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			messages[msg] = fset.Position(lit.Pos())
+			return true
+		})
+	}
+	if len(messages) == 0 {
+		t.Fatal("found no messages passed to tr")
+	}
+	for lang, catalog := range messageCatalog {
+		for msg, pos := range messages {
+			if _, ok := catalog[msg]; !ok {
+				t.Errorf("%s: %q has no %s translation", pos, msg, lang)
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// nameEndpoints maps the kinds of names we index to their list endpoints.
+var nameEndpoints = map[string]string{
+	"pokemon":  "pokemon",
+	"area":     "location-area",
+	"item":     "item",
+	"move":     "move",
+	"version":  "version",
+	"language": "language",
+}
+
+// maxSuggestions is how many "did you mean" candidates are offered.
+const maxSuggestions = 3
+
+// nameIndex returns the sorted list of every name of a kind, fetching the
+// list endpoint once and keeping it for the rest of the session.
+func nameIndex(configure *config, c *pokecache.Cache, kind string) ([]string, error) {
+	if names, ok := configure.names[kind]; ok {
+		return names, nil
+	}
+	list := namedList{}
+	if err := fetchJSON(c, pokeAPI+nameEndpoints[kind]+"?limit=100000", &list); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Results))
+	for _, r := range list.Results {
+		names = append(names, r.Name)
+	}
+	sort.Strings(names)
+	configure.names[kind] = names
+	return names, nil
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(br)]
+}
+
+// closestNames returns up to maxSuggestions names within a few edits of
+// query, closest first.
+func closestNames(names []string, query string) []string {
+	limit := max(2, len(query)/3)
+	type scored struct {
+		name     string
+		distance int
+	}
+	found := make([]scored, 0)
+	for _, name := range names {
+		if d := editDistance(query, name); d <= limit {
+			found = append(found, scored{name, d})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].distance != found[j].distance {
+			return found[i].distance < found[j].distance
+		}
+		return found[i].name < found[j].name
+	})
+	suggestions := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(found) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, found[i].name)
+	}
+	return suggestions
+}
+
+// completeName returns every indexed name of a kind starting with prefix.
+func completeName(configure *config, c *pokecache.Cache, kind, prefix string) ([]string, error) {
+	names, err := nameIndex(configure, c, kind)
+	if err != nil {
+		return nil, err
+	}
+	start := sort.SearchStrings(names, prefix)
+	end := start
+	for end < len(names) && strings.HasPrefix(names[end], prefix) {
+		end++
+	}
+	return names[start:end], nil
+}
+
+// didYouMean is the hint appended to messages about unknown names.
+func (configure *config) didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(configure.tr(", did you mean %s?"), strings.Join(suggestions, ", "))
+}
+
+// unknownNameError is a not found error with suggestions; it matches
+// errNotFound with errors.Is.
+type unknownNameError struct {
+	text string
+}
+
+func (e *unknownNameError) Error() string { return e.text }
+
+func (e *unknownNameError) Unwrap() error { return errNotFound }
+
+// notFoundError explains that name is not a known kind, with suggestions
+// when there are close matches.
+func notFoundError(configure *config, c *pokecache.Cache, kind, name string) error {
+	hint := ""
+	if names, err := nameIndex(configure, c, kind); err == nil {
+		hint = configure.didYouMean(closestNames(names, name))
+	}
+	return &unknownNameError{fmt.Sprintf("no %s named %s%s", kind, name, hint)}
+}
+
+// notCaughtHint suggests caught Pokemon close to name, or says whether the
+// species exists at all.
+func notCaughtHint(configure *config, c *pokecache.Cache, name string) string {
+	caught := make([]string, 0, len(configure.caughtPokemon))
+	for n := range configure.caughtPokemon {
+		caught = append(caught, n)
+	}
+	if suggestions := closestNames(caught, name); len(suggestions) > 0 {
+		return configure.didYouMean(suggestions)
+	}
+	if names, err := nameIndex(configure, c, "pokemon"); err == nil {
+		if i := sort.SearchStrings(names, name); i == len(names) || names[i] != name {
+			return fmt.Sprintf(configure.tr(", and there is no pokemon named %s"), name) + configure.didYouMean(closestNames(names, name))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"pikachu", "pikachu", 0},
+		{"pikachu", "pikachuu", 1},
+		{"kitten", "sitting", 3},
+		{"raichu", "pichu", 2},
+		{"flabébé", "flabebe", 2}, // counted in runes, not bytes
+	}
+	for _, tc := range cases {
+		if got := editDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+		if got := editDistance(tc.b, tc.a); got != tc.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tc.b, tc.a, got, tc.want)
+		}
+	}
+}
+
+func TestClosestNames(t *testing.T) {
+	names := []string{"bulbasaur", "pichu", "pikachu", "raichu", "charmander"}
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"pikachoo", []string{"pikachu"}},
+		{"pichu", []string{"pichu", "pikachu", "raichu"}},
+		{"bulbsaur", []string{"bulbasaur"}},
+		{"mewtwo", []string{}},
+	}
+	for _, tc := range cases {
+		if got := closestNames(names, tc.query); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("closestNames(%q) = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+// nameCache serves the pokemon name list without going online.
+func nameCache() *pokecache.Cache {
+	c := pokecache.NewCache(time.Hour)
+	c.Add(pokeAPI+"pokemon?limit=100000", []byte(`{"count":5,"results":[{"name":"pikachu"},{"name":"pichu"},{"name":"raichu"},{"name":"bulbasaur"},{"name":"charmander"}]}`))
+	return c
+}
+
+func TestCompleteName(t *testing.T) {
+	configure := &config{names: make(map[string][]string)}
+	got, err := completeName(configure, nameCache(), "pokemon", "pi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"pichu", "pikachu"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("completeName(pi) = %v, want %v", got, want)
+	}
+}
+
+func TestNotFoundError(t *testing.T) {
+	configure := &config{names: make(map[string][]string)}
+	c := nameCache()
+	cases := map[string]string{
+		"pikachoo": "no pokemon named pikachoo, did you mean pikachu?",
+		"mewtwo":   "no pokemon named mewtwo",
+	}
+	for name, want := range cases {
+		if got := notFoundError(configure, c, "pokemon", name).Error(); got != want {
+			t.Errorf("notFoundError(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
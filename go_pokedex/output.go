@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// outputFormats are the structured formats commands can print with --format.
+var outputFormats = []string{"table", "csv", "md", "json"}
+
+func checkFormat(format string) error {
+	for _, f := range outputFormats {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown format %q, pick one of %s", format, strings.Join(outputFormats, "|"))
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeTable prints rows under header as an aligned text table, CSV or a
+// Markdown table. Cells marked in best are highlighted: in bold green on a
+// color terminal, with a trailing * otherwise.
+func writeTable(w io.Writer, format string, header []string, rows [][]string, best [][]bool) error {
+	isBest := func(r, c int) bool {
+		return best != nil && r < len(best) && c < len(best[r]) && best[r][c]
+	}
+	switch format {
+	case "csv":
+		out := csv.NewWriter(w)
+		out.Write(header)
+		for _, row := range rows {
+			out.Write(row)
+		}
+		out.Flush()
+		return out.Error()
+	case "md":
+		fmt.Fprintln(w, "| "+strings.Join(header, " | ")+" |")
+		fmt.Fprintln(w, "|"+strings.Repeat(" --- |", len(header)))
+		for r, row := range rows {
+			cells := make([]string, len(row))
+			for c, cell := range row {
+				cells[c] = strings.ReplaceAll(cell, "|", "\\|")
+				if isBest(r, c) {
+					cells[c] = "**" + cells[c] + "**"
+				}
+			}
+			fmt.Fprintln(w, "| "+strings.Join(cells, " | ")+" |")
+		}
+		return nil
+	}
+
+	color := terminalColors() != colorNone
+	widths := make([]int, len(header))
+	for c, h := range header {
+		widths[c] = utf8.RuneCountInString(h)
+	}
+	for r, row := range rows {
+		for c, cell := range row {
+			n := utf8.RuneCountInString(cell)
+			if isBest(r, c) && !color {
+				n++
+			}
+			if n > widths[c] {
+				widths[c] = n
+			}
+		}
+	}
+	line := func(cells []string, r int) {
+		parts := make([]string, len(cells))
+		for c, cell := range cells {
+			if r >= 0 && isBest(r, c) && !color {
+				cell += "*"
+			}
+			pad := strings.Repeat(" ", widths[c]-utf8.RuneCountInString(cell))
+			if r >= 0 && isBest(r, c) && color {
+				cell = "\x1b[1;32m" + cell + "\x1b[0m"
+			}
+			parts[c] = cell + pad
+		}
+		fmt.Fprintln(w, strings.TrimRight(strings.Join(parts, "  "), " "))
+	}
+	line(header, -1)
+	for r, row := range rows {
+		line(row, r)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Package plugin lets other packages add commands to the pokedex REPL.
+//
+// A package registers its commands from an init function and is compiled in
+// with a blank import in the pokedex main package:
+//
+//	func init() {
+//		plugin.Register(rivalCommand{})
+//	}
+//
+// Commands can also live outside the binary: any executable on PATH named
+// pokedex-<cmd> becomes the command <cmd>. It is given a Request as JSON on
+// stdin and answers with a Result as JSON on stdout.
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// Arg documents one argument or flag of a command for the help pages.
+type Arg struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Command is a command that can be typed at the pokedex prompt.
+type Command interface {
+	// Name is the word typed to run the command. Input is lowercased
+	// before it is matched, so a name is made of lowercase letters, digits
+	// and dashes; see ValidName.
+	Name() string
+	// Help is the one line summary shown in the help listing.
+	Help() string
+	// Args documents the arguments shown on the command's help page.
+	Args() []Arg
+	// Run executes the command with the words typed after its name. The
+	// context is cancelled when the user interrupts the command.
+	Run(ctx context.Context, env Env, args []string) error
+}
+
+// Env is what a running command can see and use of the pokedex.
+type Env interface {
+	// Stdout is where the command writes its output.
+	Stdout() io.Writer
+	// State is a snapshot of the trainer's state.
+	State() State
+	// Fetch decodes a PokeAPI resource into v through the pokedex cache.
+	Fetch(ctx context.Context, url string, v any) error
+	// Prompt asks the user for a line of input.
+	Prompt(text string) string
+}
+
+// State is the trainer's state as seen by plugins.
+type State struct {
+	Area      string             `json:"area"`
+	Version   string             `json:"version"`
+	Language  string             `json:"language"`
+	Party     []string           `json:"party"`
+	Inventory map[string]int     `json:"inventory"`
+	Pokedex   map[string]Pokemon `json:"pokedex"`
+}
+
+// Pokemon is a caught Pokemon as seen by plugins.
+type Pokemon struct {
+	ID     int            `json:"id"`
+	Name   string         `json:"name"`
+	Level  int            `json:"level"`
+	Nature string         `json:"nature"`
+	Gender string         `json:"gender"`
+	Shiny  bool           `json:"shiny"`
+	Types  []string       `json:"types"`
+	Moves  []string       `json:"moves"`
+	Stats  map[string]int `json:"stats"`
+}
+
+// Request is written as JSON to the stdin of an external command.
+type Request struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+	State   State    `json:"state"`
+}
+
+// Result is read as JSON from the stdout of an external command. Output is
+// shown to the user; a non-empty Error is reported as the command's error.
+type Result struct {
+	Output string `json:"output"`
+	Error  string `json:"error,omitempty"`
+}
+
+var (
+	mu       sync.Mutex
+	registry = make(map[string]Command)
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// ValidName reports whether name can be typed at the prompt: it is not
+// empty and only has lowercase letters, digits and dashes.
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Register makes a command available at the prompt. It panics when the name
+// is not valid or a command with the same name is already registered.
+func Register(cmd Command) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !ValidName(cmd.Name()) {
+		panic(fmt.Sprintf("plugin: Register called with invalid command name %q", cmd.Name()))
+	}
+	if _, dup := registry[cmd.Name()]; dup {
+		panic(fmt.Sprintf("plugin: Register called twice for command %s", cmd.Name()))
+	}
+	registry[cmd.Name()] = cmd
+}
+
+// Commands returns the registered commands sorted by name.
+func Commands() []Command {
+	mu.Lock()
+	defer mu.Unlock()
+	list := make([]Command, 0, len(registry))
+	for _, cmd := range registry {
+		list = append(list, cmd)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+	return list
+}
@@ -0,0 +1,43 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+)
+
+type namedCommand string
+
+func (n namedCommand) Name() string { return string(n) }
+func (n namedCommand) Help() string { return "" }
+func (n namedCommand) Args() []Arg  { return nil }
+func (n namedCommand) Run(ctx context.Context, env Env, args []string) error {
+	return nil
+}
+
+func TestValidName(t *testing.T) {
+	cases := map[string]bool{
+		"rival": true, "safari-zone": true, "gen3": true,
+		"": false, "Rival": false, "safari zone": false, "rival!": false, "../rival": false,
+	}
+	for name, want := range cases {
+		if got := ValidName(name); got != want {
+			t.Errorf("ValidName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestRegisterRejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"", "Rival", "safari zone"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", name)
+				}
+			}()
+			Register(namedCommand(name))
+		}()
+	}
+	if len(Commands()) != 0 {
+		t.Errorf("invalid commands were registered: %v", Commands())
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+	"github.com/Raikoa414/go_pokedex/plugin"
+)
+
+// externalPrefix is the file name prefix of external commands on PATH.
+const externalPrefix = "pokedex-"
+
+// pluginEnv gives plugin commands access to the running pokedex.
+type pluginEnv struct {
+	configure *config
+	c         *pokecache.Cache
+}
+
+func (e *pluginEnv) Stdout() io.Writer { return os.Stdout }
+
+func (e *pluginEnv) State() plugin.State { return pluginState(e.configure) }
+
+func (e *pluginEnv) Fetch(ctx context.Context, url string, v any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return fetchJSON(e.c, url, v)
+}
+
+func (e *pluginEnv) Prompt(text string) string { return prompt(e.configure, text) }
+
+// pluginState copies the trainer's state into the form plugins see.
+func pluginState(configure *config) plugin.State {
+	state := plugin.State{
+		Area:      configure.area,
+		Version:   configure.game.Version,
+		Language:  configure.language,
+		Party:     append([]string{}, configure.party...),
+		Inventory: make(map[string]int, len(configure.inventory)),
+		Pokedex:   make(map[string]plugin.Pokemon, len(configure.caughtPokemon)),
+	}
+	for item, count := range configure.inventory {
+		state.Inventory[item] = count
+	}
+	for name, o := range configure.caughtPokemon {
+		p := plugin.Pokemon{
+			ID:     o.ID,
+			Name:   o.Name,
+			Level:  o.Level,
+			Nature: o.Nature,
+			Gender: o.Gender,
+			Shiny:  o.Shiny,
+			Types:  o.typeNames(),
+			Moves:  append([]string{}, o.KnownMoves...),
+			Stats:  make(map[string]int, len(statNames)),
+		}
+		for _, stat := range statNames {
+			p.Stats[stat] = o.stat(stat)
+		}
+		state.Pokedex[name] = p
+	}
+	return state
+}
+
+// externalCommand runs a pokedex-<cmd> executable found on PATH.
+type externalCommand struct {
+	name string
+	path string
+}
+
+func (e externalCommand) Name() string { return e.name }
+
+func (e externalCommand) Help() string { return "external command " + e.path }
+
+func (e externalCommand) Args() []plugin.Arg { return nil }
+
+func (e externalCommand) Run(ctx context.Context, env plugin.Env, args []string) error {
+	request, err := json.Marshal(plugin.Request{Command: e.name, Args: args, State: env.State()})
+	if err != nil {
+		return err
+	}
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.path)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+
+	result := plugin.Result{}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		if runErr != nil {
+			return fmt.Errorf("%s failed: %v", e.path, runErr)
+		}
+		return fmt.Errorf("%s did not answer with a json result: %v", e.path, err)
+	}
+	if result.Output != "" {
+		fmt.Fprint(env.Stdout(), result.Output)
+		if !strings.HasSuffix(result.Output, "\n") {
+			fmt.Fprintln(env.Stdout())
+		}
+	}
+	if result.Error != "" {
+		return fmt.Errorf("%s", result.Error)
+	}
+	if runErr != nil {
+		return fmt.Errorf("%s failed: %v", e.path, runErr)
+	}
+	return nil
+}
+
+// findExternalCommands lists the pokedex-<cmd> executables on PATH; when the
+// same name appears twice the first directory wins, like the shell. Names
+// that could not be typed at the prompt are skipped.
+func findExternalCommands() []plugin.Command {
+	found := make([]plugin.Command, 0)
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), externalPrefix)
+			if !ok || entry.IsDir() {
+				continue
+			}
+			if runtime.GOOS == "windows" {
+				if name, ok = strings.CutSuffix(name, ".exe"); !ok {
+					continue
+				}
+			} else if info, err := entry.Info(); err != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			if !plugin.ValidName(name) || seen[name] {
+				continue
+			}
+			seen[name] = true
+			found = append(found, externalCommand{name: name, path: filepath.Join(dir, entry.Name())})
+		}
+	}
+	return found
+}
+
+// pluginCommand adapts a plugin command to the REPL's command table.
+func pluginCommand(p plugin.Command) Commands {
+	args := make([]argSpec, 0, len(p.Args()))
+	usage := p.Name()
+	for _, arg := range p.Args() {
+		args = append(args, argSpec{arg.Name, arg.Description})
+		usage += " " + arg.Name
+	}
+	return Commands{
+		name:        p.Name(),
+		description: p.Help(),
+		usage:       usage,
+		args:        args,
+		group:       "plugins",
+		function: func(configure *config, c *pokecache.Cache, AreaName string) error {
+			// an interrupt stops the plugin instead of the whole pokedex
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			return p.Run(ctx, &pluginEnv{configure, c}, strings.Fields(AreaName))
+		},
+	}
+}
+
+// addPlugins adds the registered and external commands to the command table.
+// Built-in commands and aliases keep their names.
+func addPlugins(commands map[string]Commands) {
+	for _, p := range append(plugin.Commands(), findExternalCommands()...) {
+		if _, exists := lookupCommand(commands, p.Name()); exists {
+			if _, external := p.(externalCommand); !external {
+				fmt.Printf("plugin command %s is hidden by a built-in command\n", p.Name())
+			}
+			continue
+		}
+		commands[p.Name()] = pluginCommand(p)
+	}
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// activeProfileFile names the profile that is loaded on start.
+const activeProfileFile = "active"
+
+// profileData is what a trainer profile keeps on disk.
+type profileData struct {
+	Pokedex   map[string]*OwnedPokemon `json:"pokedex"`
+	Evolved   []string                 `json:"evolved,omitempty"` // see config.registered
+	Party     []string                 `json:"party"`
+	Inventory map[string]int           `json:"inventory"`
+	Area      string                   `json:"area"`
+	MapPage   int                      `json:"map_page"`
+	History   [][]string               `json:"map_history"`
+	Game      versionFilter            `json:"game"`
+	Language  string                   `json:"language"`
+	Trades    []tradeRecord            `json:"trades"`
+	Pending   *pendingTrade            `json:"pending_trade,omitempty"`
+}
+
+func profileDir() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "profiles"), nil
+}
+
+func profilePath(name string) (string, error) {
+	dir, err := profileDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// checkProfileName keeps profile names usable as file names.
+func checkProfileName(name string) error {
+	if name == "" || name == activeProfileFile {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return fmt.Errorf("profile names may only use letters, digits, - and _")
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic replaces path with data so that readers see either the old
+// or the new file, never a partial one.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// savedPokemon trims a caught Pokemon to what the pokedex reads back from a
+// profile: game indices and held items are dropped and moves only keep how
+// they are learnt by levelling up.
+func savedPokemon(o *OwnedPokemon) *OwnedPokemon {
+	trimmed := *o
+	trimmed.GameIndices = nil
+	trimmed.HeldItems = nil
+	trimmed.Moves = slices.Clone(o.Moves)
+	for i := range trimmed.Moves {
+		details := trimmed.Moves[i].VersionGroupDetails[:0:0]
+		for _, d := range o.Moves[i].VersionGroupDetails {
+			if d.MoveLearnMethod.Name == "level-up" {
+				details = append(details, d)
+			}
+		}
+		trimmed.Moves[i].VersionGroupDetails = details
+	}
+	return &trimmed
+}
+
+// saveProfile writes the trainer's state to the active profile, if any.
+func saveProfile(configure *config) error {
+	if configure.profile == "" {
+		return nil
+	}
+	path, err := profilePath(configure.profile)
+	if err != nil {
+		return err
+	}
+	pokedex := make(map[string]*OwnedPokemon, len(configure.caughtPokemon))
+	for name, o := range configure.caughtPokemon {
+		pokedex[name] = savedPokemon(o)
+	}
+	data, err := json.Marshal(profileData{
+		Pokedex:   pokedex,
+		Evolved:   sortedKeys(configure.registered),
+		Party:     configure.party,
+		Inventory: configure.inventory,
+		Area:      configure.area,
+		MapPage:   configure.id,
+		History:   configure.history,
+		Game:      configure.game,
+		Language:  configure.language,
+		Trades:    configure.trades,
+		Pending:   configure.pending,
+	})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+func readProfile(name string) (profileData, error) {
+	profile := profileData{}
+	path, err := profilePath(name)
+	if err != nil {
+		return profile, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return profile, err
+	}
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return profile, fmt.Errorf("profile %s is damaged: %v", name, err)
+	}
+	return profile, nil
+}
+
+// useProfile replaces the trainer's state with the saved profile name and
+// makes it the active profile.
+func useProfile(configure *config, name string) error {
+	profile, err := readProfile(name)
+	if err != nil {
+		return err
+	}
+	configure.profile = name
+	configure.caughtPokemon = profile.Pokedex
+	if configure.caughtPokemon == nil {
+		configure.caughtPokemon = make(map[string]*OwnedPokemon)
+	}
+	for _, owned := range configure.caughtPokemon {
+		if owned.UID == "" {
+			// saved before Pokemon had UIDs
+			owned.UID = newUID()
+		}
+	}
+	configure.registered = make(map[string]bool, len(profile.Evolved))
+	for _, name := range profile.Evolved {
+		configure.registered[name] = true
+	}
+	configure.party = profile.Party
+	configure.inventory = profile.Inventory
+	if configure.inventory == nil {
+		configure.inventory = make(map[string]int)
+	}
+	configure.area = profile.Area
+	configure.id = max(profile.MapPage, 1)
+	configure.history = profile.History
+	if configure.history == nil {
+		configure.history = make([][]string, 0)
+	}
+	configure.game = profile.Game
+	configure.trades = profile.Trades
+	configure.pending = profile.Pending
+	if configure.language != profile.Language {
+		configure.language = profile.Language
+		configure.localNames = make(map[string]string)
+	}
+	return setActiveProfile(name)
+}
+
+func setActiveProfile(name string) error {
+	dir, err := profileDir()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, activeProfileFile), []byte(name+"\n"))
+}
+
+// loadActiveProfile loads the profile used last, if there is one.
+func loadActiveProfile(configure *config) error {
+	dir, err := profileDir()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, activeProfileFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(string(data))
+	if name == "" {
+		return nil
+	}
+	return useProfile(configure, name)
+}
+
+func profileNames() ([]string, error) {
+	dir, err := profileDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !entry.IsDir() {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func profileExists(name string) bool {
+	path, err := profilePath(name)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// promptText is the REPL prompt, naming the active profile.
+func promptText(configure *config) string {
+	if configure.profile == "" {
+		return "pokedex > "
+	}
+	return "pokedex [" + configure.profile + "] > "
+}
+
+func commandProfile(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	name := ""
+	if len(args) > 1 {
+		name = args[1]
+	}
+	switch args[0] {
+	case "list":
+		names, err := profileNames()
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Println(configure.tr("no profiles yet, create one with profile new <name>"))
+		}
+		for _, n := range names {
+			if n == configure.profile {
+				fmt.Printf(" * %s\n", n)
+			} else {
+				fmt.Printf("   %s\n", n)
+			}
+		}
+		return nil
+	case "new":
+		if err := checkProfileName(name); err != nil {
+			return err
+		}
+		if profileExists(name) {
+			return fmt.Errorf("profile %s already exists, use profile switch %s", name, name)
+		}
+		if configure.profile != "" {
+			// start the new trainer from scratch
+			if err := saveProfile(configure); err != nil {
+				return err
+			}
+			configure.caughtPokemon = make(map[string]*OwnedPokemon)
+			configure.registered = nil
+			configure.party = nil
+			configure.inventory = make(map[string]int)
+			configure.area = ""
+			configure.id = 1
+			configure.history = make([][]string, 0)
+			configure.trades = nil
+			configure.pending = nil
+		} else if len(configure.caughtPokemon) > 0 {
+			fmt.Printf(configure.tr("your progress so far is kept in %s")+"\n", name)
+		}
+		configure.profile = name
+		if err := saveProfile(configure); err != nil {
+			return err
+		}
+		fmt.Printf(configure.tr("created profile %s")+"\n", name)
+		return setActiveProfile(name)
+	case "switch":
+		if err := checkProfileName(name); err != nil {
+			return err
+		}
+		if !profileExists(name) {
+			names, _ := profileNames()
+			return fmt.Errorf("no profile named %s%s", name, configure.didYouMean(closestNames(names, name)))
+		}
+		if configure.profile == "" && len(configure.caughtPokemon)+len(configure.inventory) > 0 {
+			answer := prompt(configure, fmt.Sprintf(configure.tr("your progress so far is not in a profile and will be lost, switch to %s anyway? (y/n) > "), name))
+			if answer != "y" && answer != "yes" {
+				fmt.Println(configure.tr("stayed, keep your progress with profile new <name>"))
+				return nil
+			}
+		}
+		if err := saveProfile(configure); err != nil {
+			return err
+		}
+		if err := useProfile(configure, name); err != nil {
+			return err
+		}
+		fmt.Printf(configure.tr("switched to %s, %d pokemon caught")+"\n", name, len(configure.caughtPokemon))
+		return nil
+	case "delete":
+		if err := checkProfileName(name); err != nil {
+			return err
+		}
+		if !profileExists(name) {
+			return fmt.Errorf("no profile named %s", name)
+		}
+		if name == configure.profile {
+			return fmt.Errorf("%s is the active profile, switch to another one first", name)
+		}
+		if answer := prompt(configure, fmt.Sprintf(configure.tr("delete %s and every pokemon in it? (y/n) > "), name)); answer != "y" && answer != "yes" {
+			fmt.Printf(configure.tr("kept %s")+"\n", name)
+			return nil
+		}
+		path, err := profilePath(name)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		fmt.Printf(configure.tr("deleted profile %s")+"\n", name)
+		return nil
+	}
+	return usageError(configure, "profile")
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+type regionData struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Locations []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"locations"`
+}
+
+type locationData struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Region struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"region"`
+	Areas []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"areas"`
+}
+
+func fetchLocation(c *pokecache.Cache, name string) (locationData, error) {
+	location := locationData{}
+	err := fetchJSON(c, pokeAPI+"location/"+name, &location)
+	return location, err
+}
+
+func commandRegions(configure *config, c *pokecache.Cache, AreaName string) error {
+	list := namedList{}
+	if err := fetchJSON(c, pokeAPI+"region?limit=100", &list); err != nil {
+		return fmt.Errorf("unable to get regions: %v", err)
+	}
+	for _, r := range list.Results {
+		fmt.Println(configure.localizeListed(c, "region", r.Name))
+	}
+	return nil
+}
+
+func commandLocations(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) != 1 {
+		return usageError(configure, "locations")
+	}
+	region := regionData{}
+	err := fetchJSON(c, pokeAPI+"region/"+args[0], &region)
+	if err == errNotFound {
+		return fmt.Errorf("no region named %s, see the regions command", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	for _, l := range region.Locations {
+		fmt.Println(configure.localizeListed(c, "location", l.Name))
+	}
+	return nil
+}
+
+func commandAreas(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) != 1 {
+		return usageError(configure, "areas")
+	}
+	location, err := fetchLocation(c, args[0])
+	if err == errNotFound {
+		return fmt.Errorf("no location named %s, see the locations command", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	if len(location.Areas) == 0 {
+		fmt.Printf(configure.tr("%s has no areas with wild pokemon")+"\n", configure.localize(c, "location", location.Name))
+	}
+	for _, a := range location.Areas {
+		fmt.Println(configure.localizeListed(c, "location-area", a.Name))
+	}
+	return nil
+}
+
+// commandTravel moves the trainer to an area; explore and battle use it when
+// no area is given.
+func commandTravel(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) != 1 {
+		if configure.area == "" {
+			fmt.Println(configure.tr("you have not travelled anywhere yet"))
+		} else {
+			fmt.Printf(configure.tr("you are at %s")+"\n", configure.localize(c, "location-area", configure.area))
+		}
+		return nil
+	}
+	area, err := fetchLocationArea(c, args[0])
+	if err == errNotFound {
+		return notFoundError(configure, c, "area", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	configure.area = area.Name
+	where := configure.localize(c, "location", area.Location.Name)
+	if location, err := fetchLocation(c, area.Location.Name); err == nil && location.Region.Name != "" {
+		where += ", " + configure.localize(c, "region", location.Region.Name)
+	}
+	fmt.Printf(configure.tr("you travelled to %s (%s)")+"\n", area.displayName(configure.language), where)
+	return nil
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// exportFormats are the formats the export command writes.
+var exportFormats = []string{"csv", "md", "html", "json"}
+
+//go:embed report.html
+var reportTemplate string
+
+// pokedexEntry is one caught Pokemon in an exported report.
+type pokedexEntry struct {
+	ID        int            `json:"id"`
+	Name      string         `json:"name"`
+	UID       string         `json:"uid"`
+	Level     int            `json:"level"`
+	Gender    string         `json:"gender"`
+	Shiny     bool           `json:"shiny"`
+	Types     []string       `json:"types"`
+	Abilities []string       `json:"abilities"`
+	BaseStats map[string]int `json:"base_stats"`
+	Total     int            `json:"base_stat_total"`
+	Caught    string         `json:"caught,omitempty"`
+	Location  string         `json:"location,omitempty"`
+
+	// used by the HTML report only
+	Stats  []int        `json:"-"`
+	Sprite template.URL `json:"-"` // inlined data URL, trusted by the template
+}
+
+func reportHeader() []string {
+	header := []string{"id", "name", "level", "gender", "types", "abilities"}
+	header = append(header, statNames...)
+	return append(header, "total", "caught", "location")
+}
+
+func (e pokedexEntry) row() []string {
+	name := e.Name
+	if e.Shiny {
+		name += " ★"
+	}
+	row := []string{strconv.Itoa(e.ID), name, strconv.Itoa(e.Level), e.Gender, strings.Join(e.Types, ", "), strings.Join(e.Abilities, ", ")}
+	for _, stat := range e.Stats {
+		row = append(row, strconv.Itoa(stat))
+	}
+	return append(row, strconv.Itoa(e.Total), e.Caught, e.Location)
+}
+
+// pokedexEntries lists the caught Pokemon ordered by pokedex number.
+func pokedexEntries(configure *config, c *pokecache.Cache) []pokedexEntry {
+	entries := make([]pokedexEntry, 0, len(configure.caughtPokemon))
+	for _, o := range configure.caughtPokemon {
+		e := pokedexEntry{
+			ID:        o.ID,
+			Name:      configure.pokemonName(c, o.Name),
+			UID:       o.UID,
+			Level:     o.Level,
+			Gender:    o.Gender,
+			Shiny:     o.Shiny,
+			Types:     o.typeNames(),
+			BaseStats: make(map[string]int, len(statNames)),
+			Location:  configure.localize(c, "location-area", o.CaughtIn),
+		}
+		for _, a := range o.Abilities {
+			ability := a.Ability.Name
+			if a.IsHidden {
+				ability += " (hidden)"
+			}
+			e.Abilities = append(e.Abilities, ability)
+		}
+		for _, stat := range statNames {
+			e.BaseStats[stat] = o.baseStat(stat)
+			e.Stats = append(e.Stats, o.baseStat(stat))
+			e.Total += o.baseStat(stat)
+		}
+		if !o.CaughtAt.IsZero() {
+			e.Caught = o.CaughtAt.Format("2006-01-02 15:04")
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ID != entries[j].ID {
+			return entries[i].ID < entries[j].ID
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// writeHTMLReport writes a standalone page with the sprites inlined, so it can
+// be shared without the pokedex or a network connection.
+func writeHTMLReport(w io.Writer, configure *config, c *pokecache.Cache, entries []pokedexEntry) error {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{"join": strings.Join}).Parse(reportTemplate)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		owned, exists := findOwned(configure, entries[i].UID)
+		if !exists || owned.spriteURL() == "" {
+			continue
+		}
+		body, contentType, err := fetchAsset(c, owned.spriteURL())
+		if err != nil {
+			fmt.Printf("("+configure.tr("no sprite for %s: %v")+")\n", configure.pokemonName(c, owned.Name), err)
+			continue
+		}
+		entries[i].Sprite = template.URL("data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(body))
+	}
+	title := "Pokedex"
+	if configure.profile != "" {
+		title = configure.profile + "'s Pokedex"
+	}
+	return tmpl.Execute(w, struct {
+		Title    string
+		Exported time.Time
+		Header   []string
+		Pokemon  []pokedexEntry
+	}{title, time.Now(), reportHeader(), entries})
+}
+
+func commandExport(configure *config, c *pokecache.Cache, AreaName string) error {
+	flags, args := parseArgs(AreaName)
+	if len(args) != 1 {
+		return usageError(configure, "export")
+	}
+	path := args[0]
+	format, ok := flags["format"]
+	format = strings.ToLower(format)
+	if !ok {
+		// guess from the extension, pokedex.md is a Markdown report
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	if format == "htm" {
+		format = "html"
+	}
+	known := false
+	for _, f := range exportFormats {
+		known = known || f == format
+	}
+	if !known {
+		return fmt.Errorf("unknown format %q, pick one of --format=%s", format, strings.Join(exportFormats, "|"))
+	}
+	if len(configure.caughtPokemon) == 0 {
+		return fmt.Errorf("you have not caught any pokemon yet")
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+
+	entries := pokedexEntries(configure, c)
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "json":
+		err = writeJSON(&buf, entries)
+	case "html":
+		err = writeHTMLReport(&buf, configure, c, entries)
+	default:
+		rows := make([][]string, 0, len(entries))
+		for _, e := range entries {
+			rows = append(rows, e.row())
+		}
+		err = writeTable(&buf, format, reportHeader(), rows, nil)
+	}
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, buf.Bytes()); err != nil {
+		return err
+	}
+	fmt.Printf(configure.tr("wrote %d pokemon to %s")+"\n", len(entries), path)
+	return nil
+}
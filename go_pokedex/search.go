@@ -0,0 +1,335 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// searchCondition is one term of a search query such as "type:fire" or
+// "stat.speed>100".
+type searchCondition struct {
+	field string
+	op    string
+	value string
+}
+
+var conditionPattern = regexp.MustCompile(`^([a-z_.-]+)(>=|<=|!=|>|<|=|:)(.+)$`)
+
+func parseCondition(term string) (searchCondition, error) {
+	m := conditionPattern.FindStringSubmatch(term)
+	if m == nil {
+		return searchCondition{}, fmt.Errorf("can't understand %q, expected field:value or field>number", term)
+	}
+	return searchCondition{field: m[1], op: m[2], value: m[3]}, nil
+}
+
+// searchCandidate is a Pokemon a query runs against, owned is nil for
+// species that were not caught.
+type searchCandidate struct {
+	poke  Pokemon
+	owned *OwnedPokemon
+}
+
+// numericFields are the fields number understands besides stat.<name>.
+var numericFields = []string{"id", "height", "weight", "base_exp", "base_experience", "total", "level"}
+
+// isNumericField reports whether field is compared as a number, which also
+// makes it a field results can be sorted on.
+func isNumericField(field string) bool {
+	if stat, ok := strings.CutPrefix(field, "stat."); ok {
+		return slices.Contains(statNames, stat)
+	}
+	return slices.Contains(numericFields, field)
+}
+
+// number returns the value of a numeric field.
+func (sc searchCandidate) number(field string) (float64, bool) {
+	p := sc.poke
+	if stat, ok := strings.CutPrefix(field, "stat."); ok {
+		for _, s := range p.Stats {
+			if s.Stat.Name == stat {
+				return float64(s.BaseStat), true
+			}
+		}
+		return 0, false
+	}
+	switch field {
+	case "id":
+		return float64(p.ID), true
+	case "height":
+		return float64(p.Height), true
+	case "weight":
+		return float64(p.Weight), true
+	case "base_exp", "base_experience":
+		return float64(p.BaseExperience), true
+	case "total":
+		total := 0
+		for _, s := range p.Stats {
+			total += s.BaseStat
+		}
+		return float64(total), true
+	case "level":
+		if sc.owned == nil {
+			return 0, false
+		}
+		return float64(sc.owned.Level), true
+	}
+	return 0, false
+}
+
+// text returns the values of a text field; fields like type can have several.
+func (sc searchCandidate) text(field string) ([]string, bool) {
+	p := sc.poke
+	switch field {
+	case "name":
+		return []string{p.Name}, true
+	case "type":
+		return p.typeNames(), true
+	case "ability":
+		abilities := make([]string, 0, len(p.Abilities))
+		for _, a := range p.Abilities {
+			abilities = append(abilities, a.Ability.Name)
+		}
+		return abilities, true
+	case "move":
+		moves := make([]string, 0, len(p.Moves))
+		for _, m := range p.Moves {
+			moves = append(moves, m.Move.Name)
+		}
+		return moves, true
+	case "caught":
+		return []string{strconv.FormatBool(sc.owned != nil)}, true
+	case "shiny":
+		return []string{strconv.FormatBool(sc.owned != nil && sc.owned.Shiny)}, true
+	case "gender", "nature":
+		if sc.owned == nil {
+			return nil, true
+		}
+		if field == "gender" {
+			return []string{sc.owned.Gender}, true
+		}
+		return []string{sc.owned.Nature}, true
+	}
+	return nil, false
+}
+
+func (sc searchCandidate) matches(cond searchCondition) (bool, error) {
+	if values, ok := sc.text(cond.field); ok {
+		if cond.op != ":" && cond.op != "=" && cond.op != "!=" {
+			return false, fmt.Errorf("%s can only be compared with : or !=", cond.field)
+		}
+		found := false
+		for _, v := range values {
+			if v == cond.value || (cond.field == "name" && strings.Contains(v, cond.value)) {
+				found = true
+			}
+		}
+		return found == (cond.op != "!="), nil
+	}
+	got, ok := sc.number(cond.field)
+	if !ok && !isNumericField(cond.field) {
+		return false, fmt.Errorf("unknown field %q", cond.field)
+	}
+	want, err := strconv.ParseFloat(cond.value, 64)
+	if err != nil {
+		return false, fmt.Errorf("%s needs a number, got %q", cond.field, cond.value)
+	}
+	if !ok {
+		return false, nil
+	}
+	switch cond.op {
+	case ">":
+		return got > want, nil
+	case "<":
+		return got < want, nil
+	case ">=":
+		return got >= want, nil
+	case "<=":
+		return got <= want, nil
+	case "!=":
+		return got != want, nil
+	}
+	return got == want, nil
+}
+
+// cachedSpecies returns the Pokemon looked up before that were not caught:
+// the species fetchPokemon left in the on-disk asset cache, those evolved
+// away from and those traded away. The in-memory cache is no use here, it
+// forgets everything within a minute.
+func cachedSpecies(configure *config, c *pokecache.Cache) []Pokemon {
+	names := make(map[string]bool)
+	for name := range configure.registered {
+		names[name] = true
+	}
+	for _, t := range configure.trades {
+		names[t.Received] = true
+		if t.Gave != "?" {
+			names[t.Gave] = true
+		}
+	}
+	if c.Assets != nil {
+		prefix := pokeAPI + "pokemon/"
+		for _, key := range c.Assets.Keys() {
+			if name, ok := strings.CutPrefix(key, prefix); ok && !strings.ContainsAny(name, "/?") {
+				names[name] = true
+			}
+		}
+	}
+	found := make([]Pokemon, 0, len(names))
+	for _, name := range sortedKeys(names) {
+		if _, owned := configure.caughtPokemon[name]; owned {
+			continue
+		}
+		if poke, err := fetchPokemon(c, name); err == nil {
+			found = append(found, poke)
+		}
+	}
+	return found
+}
+
+func commandSearch(configure *config, c *pokecache.Cache, AreaName string) error {
+	flags, terms := parseArgs(AreaName)
+	format := "table"
+	if f, ok := flags["format"]; ok {
+		format = f
+	}
+	if err := checkFormat(format); err != nil {
+		return err
+	}
+	conditions := make([]searchCondition, 0, len(terms))
+	for _, term := range terms {
+		cond, err := parseCondition(term)
+		if err != nil {
+			return err
+		}
+		conditions = append(conditions, cond)
+	}
+
+	candidates := make([]searchCandidate, 0)
+	seen := make(map[string]bool)
+	for _, owned := range configure.caughtPokemon {
+		candidates = append(candidates, searchCandidate{poke: owned.Pokemon, owned: owned})
+		seen[owned.Name] = true
+	}
+	if _, all := flags["all"]; all {
+		for _, poke := range cachedSpecies(configure, c) {
+			if !seen[poke.Name] {
+				candidates = append(candidates, searchCandidate{poke: poke})
+				seen[poke.Name] = true
+			}
+		}
+	}
+
+	results := make([]searchCandidate, 0)
+	for _, sc := range candidates {
+		keep := true
+		for _, cond := range conditions {
+			ok, err := sc.matches(cond)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			results = append(results, sc)
+		}
+	}
+
+	// --sort takes comma separated fields, a leading - sorts descending.
+	sortKeys := []string{"id"}
+	if s, ok := flags["sort"]; ok && s != "" {
+		sortKeys = strings.Split(s, ",")
+	}
+	for _, key := range sortKeys {
+		if field := strings.TrimPrefix(key, "-"); field != "name" && !isNumericField(field) {
+			return fmt.Errorf("can't sort by %q, use name or a numeric field such as total or stat.speed", field)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		for _, key := range sortKeys {
+			field, desc := strings.TrimPrefix(key, "-"), strings.HasPrefix(key, "-")
+			a, _ := results[i].number(field)
+			b, _ := results[j].number(field)
+			if a == b {
+				if field != "name" {
+					continue
+				}
+				if results[i].poke.Name == results[j].poke.Name {
+					continue
+				}
+				return (results[i].poke.Name < results[j].poke.Name) != desc
+			}
+			return (a < b) != desc
+		}
+		return false
+	})
+	if l, ok := flags["limit"]; ok {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid limit %q", l)
+		}
+		if n < len(results) {
+			results = results[:n]
+		}
+	}
+
+	// show the numeric fields the query used next to the defaults
+	columns := []string{"id", "total"}
+	for _, key := range sortKeys {
+		columns = appendColumn(columns, strings.TrimPrefix(key, "-"))
+	}
+	for _, cond := range conditions {
+		if _, isText := (searchCandidate{}).text(cond.field); !isText {
+			columns = appendColumn(columns, cond.field)
+		}
+	}
+
+	header := append([]string{"name", "caught", "types"}, columns...)
+	rows := make([][]string, 0, len(results))
+	records := make([]map[string]any, 0, len(results))
+	for _, sc := range results {
+		row := []string{sc.poke.Name, strconv.FormatBool(sc.owned != nil), strings.Join(sc.poke.typeNames(), "/")}
+		record := map[string]any{"name": sc.poke.Name, "caught": sc.owned != nil, "types": sc.poke.typeNames()}
+		for _, col := range columns {
+			v, ok := sc.number(col)
+			if !ok {
+				row = append(row, "-")
+				continue
+			}
+			row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+			record[col] = v
+		}
+		rows = append(rows, row)
+		records = append(records, record)
+	}
+	if format == "json" {
+		return writeJSON(os.Stdout, records)
+	}
+	if len(results) == 0 && format == "table" {
+		fmt.Println(configure.tr("no pokemon match"))
+		return nil
+	}
+	return writeTable(os.Stdout, format, header, rows, nil)
+}
+
+func appendColumn(columns []string, field string) []string {
+	if field == "name" {
+		return columns
+	}
+	for _, c := range columns {
+		if c == field {
+			return columns
+		}
+	}
+	return append(columns, field)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+func TestParseCondition(t *testing.T) {
+	cases := []struct {
+		term string
+		want searchCondition
+		ok   bool
+	}{
+		{"type:fire", searchCondition{"type", ":", "fire"}, true},
+		{"stat.speed>=100", searchCondition{"stat.speed", ">=", "100"}, true},
+		{"total<300", searchCondition{"total", "<", "300"}, true},
+		{"name!=pika", searchCondition{"name", "!=", "pika"}, true},
+		{"base_exp=64", searchCondition{"base_exp", "=", "64"}, true},
+		{"fire", searchCondition{}, false},
+		{"type:", searchCondition{}, false},
+		{">100", searchCondition{}, false},
+	}
+	for _, tc := range cases {
+		got, err := parseCondition(tc.term)
+		if (err == nil) != tc.ok {
+			t.Errorf("parseCondition(%q) error = %v, want ok=%v", tc.term, err, tc.ok)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("parseCondition(%q) = %+v, want %+v", tc.term, got, tc.want)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	charmander := searchCandidate{poke: testPokemon(t, "charmander", []string{"fire"}, [6]int{39, 52, 43, 60, 50, 65})}
+	charmander.poke.ID = 4
+	owned := searchCandidate{poke: charmander.poke, owned: &OwnedPokemon{Pokemon: charmander.poke, Level: 12, Shiny: true, Nature: "bold"}}
+	cases := []struct {
+		candidate searchCandidate
+		term      string
+		want      bool
+		ok        bool
+	}{
+		{charmander, "type:fire", true, true},
+		{charmander, "type!=fire", false, true},
+		{charmander, "type=water", false, true},
+		{charmander, "name:char", true, true},
+		{charmander, "stat.speed>60", true, true},
+		{charmander, "stat.speed<=60", false, true},
+		{charmander, "total=309", true, true},
+		{charmander, "id>=4", true, true},
+		{charmander, "caught:false", true, true},
+		{charmander, "level>5", false, true}, // not caught, no level
+		{owned, "level>5", true, true},
+		{owned, "shiny:true", true, true},
+		{owned, "nature:bold", true, true},
+		{charmander, "type>3", false, false},
+		{charmander, "stat.speed:fast", false, false},
+		{charmander, "colour:red", false, false},
+		{charmander, "stat.atack>50", false, false},
+	}
+	for _, tc := range cases {
+		cond, err := parseCondition(tc.term)
+		if err != nil {
+			t.Fatalf("parseCondition(%q): %v", tc.term, err)
+		}
+		got, err := tc.candidate.matches(cond)
+		if (err == nil) != tc.ok {
+			t.Errorf("%q: error = %v, want ok=%v", tc.term, err, tc.ok)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%q matched %v, want %v", tc.term, got, tc.want)
+		}
+	}
+}
+
+// captureStdout returns what f prints.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+	done := make(chan []byte)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- out
+	}()
+	f()
+	w.Close()
+	return string(<-done)
+}
+
+func TestCommandSearch(t *testing.T) {
+	configure := &config{caughtPokemon: make(map[string]*OwnedPokemon)}
+	for i, p := range []struct {
+		name string
+		typ  string
+		base [6]int
+	}{
+		{"charmander", "fire", [6]int{39, 52, 43, 60, 50, 65}},
+		{"squirtle", "water", [6]int{44, 48, 65, 50, 64, 43}},
+		{"vulpix", "fire", [6]int{38, 41, 40, 50, 65, 65}},
+		{"ponyta", "fire", [6]int{50, 85, 55, 65, 65, 90}},
+	} {
+		poke := testPokemon(t, p.name, []string{p.typ}, p.base)
+		poke.ID = i + 1
+		configure.caughtPokemon[p.name] = &OwnedPokemon{Pokemon: poke, Level: 5}
+	}
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"type:fire --format=json", []string{"charmander", "vulpix", "ponyta"}},
+		{"type:fire --sort=-total --format=json", []string{"ponyta", "charmander", "vulpix"}},
+		{"type:fire --sort=-total --limit=2 --format=json", []string{"ponyta", "charmander"}},
+		{"total<310 --sort=name --format=json", []string{"charmander", "vulpix"}},
+		{"type:grass --format=json", []string{}},
+	}
+	for _, tc := range cases {
+		var err error
+		out := captureStdout(t, func() { err = commandSearch(configure, nil, tc.query) })
+		if err != nil {
+			t.Errorf("search %s: %v", tc.query, err)
+			continue
+		}
+		records := make([]struct{ Name string }, 0)
+		if err := json.Unmarshal([]byte(out), &records); err != nil {
+			t.Errorf("search %s printed %q: %v", tc.query, out, err)
+			continue
+		}
+		got := make([]string, 0, len(records))
+		for _, r := range records {
+			got = append(got, r.Name)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("search %s = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestIsNumericField(t *testing.T) {
+	cases := map[string]bool{
+		"id": true, "total": true, "level": true, "base_exp": true,
+		"stat.speed": true, "stat.special-attack": true,
+		"stat.atack": false, "stat.": false, "type": false, "name": false, "": false,
+	}
+	for field, want := range cases {
+		if got := isNumericField(field); got != want {
+			t.Errorf("isNumericField(%q) = %v, want %v", field, got, want)
+		}
+	}
+}
+
+func TestSearchAllFindsSpeciesFetchedEarlier(t *testing.T) {
+	dir := t.TempDir()
+	session := func() *pokecache.Cache {
+		assets, err := pokecache.NewAssetCache(dir, assetBudget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := pokecache.NewCache(time.Hour)
+		c.Assets = assets
+		return c
+	}
+
+	// an earlier session looked up eevee without catching it
+	eevee := testPokemon(t, "eevee", []string{"normal"}, [6]int{55, 55, 50, 45, 65, 55})
+	data, err := json.Marshal(eevee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	earlier := session()
+	earlier.Add(pokeAPI+"pokemon/eevee", data)
+	if _, err := fetchPokemon(earlier, "eevee"); err != nil {
+		t.Fatal(err)
+	}
+
+	// this session starts with an empty memory cache
+	c := session()
+	configure := &config{caughtPokemon: make(map[string]*OwnedPokemon)}
+	poke := testPokemon(t, "charmander", []string{"fire"}, [6]int{39, 52, 43, 60, 50, 65})
+	configure.caughtPokemon["charmander"] = &OwnedPokemon{Pokemon: poke, Level: 5}
+	for query, want := range map[string][]string{
+		"--sort=name --format=json":       {"charmander"},
+		"--all --sort=name --format=json": {"charmander", "eevee"},
+	} {
+		var err error
+		out := captureStdout(t, func() { err = commandSearch(configure, c, query) })
+		if err != nil {
+			t.Fatalf("search %s: %v", query, err)
+		}
+		records := make([]struct{ Name string }, 0)
+		if err := json.Unmarshal([]byte(out), &records); err != nil {
+			t.Fatalf("search %s printed %q: %v", query, out, err)
+		}
+		got := make([]string, 0, len(records))
+		for _, r := range records {
+			got = append(got, r.Name)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("search %s = %v, want %v", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// areasPerPage matches the page size of the map command.
+const areasPerPage = 20
+
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// webUI is the browser front end served at /, built only on the JSON API.
+//
+//go:embed web
+var webUI embed.FS
+
+// server exposes the REPL's operations as a JSON API. The trainer state is
+// shared by every request, so handlers hold mu while they use configure.
+// They fetch from PokeAPI before taking it, so a slow lookup does not hold
+// up every other request.
+type server struct {
+	mu        sync.Mutex
+	configure *config
+	c         *pokecache.Cache
+}
+
+// statReport is one stat of an inspected Pokemon.
+type statReport struct {
+	Name  string `json:"name"`
+	Base  int    `json:"base"`
+	Value int    `json:"value"`
+	IV    int    `json:"iv"`
+	EV    int    `json:"ev"`
+}
+
+// pokemonReport is what the inspect command shows, as JSON.
+type pokemonReport struct {
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	DisplayName  string       `json:"display_name"`
+	Level        int          `json:"level"`
+	Exp          int          `json:"exp"`
+	NextLevelExp int          `json:"next_level_exp,omitempty"`
+	Nature       string       `json:"nature"`
+	Friendship   int          `json:"friendship"`
+	Gender       string       `json:"gender"`
+	Shiny        bool         `json:"shiny"`
+	Height       int          `json:"height"`
+	Weight       int          `json:"weight"`
+	Types        []string     `json:"types"`
+	Moves        []string     `json:"moves"`
+	Stats        []statReport `json:"stats"`
+	Sprite       string       `json:"sprite"`
+}
+
+func (s *server) report(o *OwnedPokemon) pokemonReport {
+	r := pokemonReport{
+		ID:          o.ID,
+		Name:        o.Name,
+		DisplayName: s.configure.pokemonName(s.c, o.Name),
+		Level:       o.Level,
+		Exp:         o.Exp,
+		Nature:      o.Nature,
+		Friendship:  o.Friendship,
+		Gender:      o.Gender,
+		Shiny:       o.Shiny,
+		Height:      o.Height,
+		Weight:      o.Weight,
+		Types:       o.typeNames(),
+		Moves:       append([]string{}, o.KnownMoves...),
+		Sprite:      o.spriteURL(),
+	}
+	if o.Level < maxLevel {
+		r.NextLevelExp = expForLevel(o.GrowthRate, o.Level+1)
+	}
+	for _, name := range statNames {
+		r.Stats = append(r.Stats, statReport{name, o.baseStat(name), o.stat(name), o.IVs[name], o.EVs[name]})
+	}
+	return r
+}
+
+// save keeps the active profile up to date after a request changed the
+// trainer's state; the caller holds mu.
+func (s *server) save() {
+	if err := saveProfile(s.configure); err != nil {
+		log.Println("saving profile:", err)
+	}
+}
+
+func writeJSONResponse(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println("writing response:", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSONResponse(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeFetchError reports a failed PokeAPI lookup, 404 when the resource does
+// not exist.
+func writeFetchError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeError(w, http.StatusBadGateway, err)
+}
+
+func (s *server) handleAreas(w http.ResponseWriter, r *http.Request) {
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid page %q", p))
+			return
+		}
+		page = n
+	}
+	list := namedList{}
+	url := fmt.Sprintf("%slocation-area?offset=%d&limit=%d", pokeAPI, (page-1)*areasPerPage, areasPerPage)
+	if err := fetchJSON(s.c, url, &list); err != nil {
+		writeFetchError(w, err)
+		return
+	}
+	names := make([]string, 0, len(list.Results))
+	for _, a := range list.Results {
+		names = append(names, a.Name)
+	}
+	pages := (list.Count + areasPerPage - 1) / areasPerPage
+	writeJSONResponse(w, http.StatusOK, map[string]any{"page": page, "pages": pages, "count": list.Count, "areas": names})
+}
+
+// handleExplore lists the encounters of an area like explore, without
+// moving the trainer there; see handleTravel.
+func (s *server) handleExplore(w http.ResponseWriter, r *http.Request) {
+	name, version := r.PathValue("name"), r.URL.Query().Get("version")
+	// fetch before taking mu, the lookups below then come from the cache
+	location, err := fetchLocationArea(s.c, name)
+	if version != "" && version != "all" {
+		fetchJSON(s.c, pokeAPI+"version/"+version, &versionData{})
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == errNotFound {
+		err = notFoundError(s.configure, s.c, "area", name)
+	}
+	if err != nil {
+		writeFetchError(w, err)
+		return
+	}
+	flags := make(map[string]string)
+	if version != "" {
+		flags["version"] = version
+	}
+	filter, err := gameFilter(s.configure, s.c, flags)
+	if err != nil {
+		writeFetchError(w, err)
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, map[string]any{
+		"name":         location.Name,
+		"display_name": location.displayName(s.configure.language),
+		"methods":      groupEncounters(location, filter),
+	})
+}
+
+// handleTravel makes an area the trainer's current area, where caught
+// Pokemon are recorded as caught in.
+func (s *server) handleTravel(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	location, err := fetchLocationArea(s.c, name)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == errNotFound {
+		err = notFoundError(s.configure, s.c, "area", name)
+	}
+	if err != nil {
+		writeFetchError(w, err)
+		return
+	}
+	s.configure.area = location.Name
+	s.save()
+	writeJSONResponse(w, http.StatusOK, map[string]any{
+		"area":         location.Name,
+		"display_name": location.displayName(s.configure.language),
+	})
+}
+
+func (s *server) handleCatch(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	// fetch the Pokemon and its species before taking mu, so the catch
+	// itself finds them in the cache
+	poke, err := fetchPokemon(s.c, name)
+	if err == nil {
+		fetchSpecies(s.c, poke.Species.URL)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err == errNotFound {
+		err = notFoundError(s.configure, s.c, "pokemon", name)
+	}
+	if err != nil {
+		writeFetchError(w, err)
+		return
+	}
+	var narration bytes.Buffer
+	result, err := throwPokeball(s.configure, s.c, poke, &narration)
+	if err != nil {
+		writeFetchError(w, err)
+		return
+	}
+	response := map[string]any{
+		"pokemon":            result.Pokemon,
+		"caught":             result.Caught,
+		"already_registered": result.AlreadyRegistered,
+		"joined_party":       result.JoinedParty,
+		"messages":           strings.Split(strings.TrimSpace(narration.String()), "\n"),
+	}
+	s.save()
+	status := http.StatusOK
+	if result.Owned != nil {
+		response["owned"] = s.report(result.Owned)
+		status = http.StatusCreated
+	}
+	writeJSONResponse(w, status, response)
+}
+
+func (s *server) handlePokedex(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := sortedKeys(s.configure.caughtPokemon)
+	reports := make([]pokemonReport, 0, len(names))
+	for _, name := range names {
+		reports = append(reports, s.report(s.configure.caughtPokemon[name]))
+	}
+	party := append([]string{}, s.configure.party...)
+	sort.SliceStable(reports, func(i, j int) bool { return reports[i].ID < reports[j].ID })
+	writeJSONResponse(w, http.StatusOK, map[string]any{"count": len(reports), "party": party, "pokemon": reports})
+}
+
+func (s *server) handleInspect(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := r.PathValue("name")
+	owned, exists := s.configure.caughtPokemon[name]
+	if !exists {
+		writeError(w, http.StatusNotFound, fmt.Errorf("you have not caught %s%s", name, notCaughtHint(s.configure, s.c, name)))
+		return
+	}
+	writeJSONResponse(w, http.StatusOK, s.report(owned))
+}
+
+// handleSprite proxies the sprite of a caught Pokemon through the asset
+// cache, so the web UI needs nothing from other hosts.
+func (s *server) handleSprite(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	owned, exists := s.configure.caughtPokemon[r.PathValue("name")]
+	url := ""
+	if exists {
+		url = owned.spriteURL()
+	}
+	s.mu.Unlock()
+	if url == "" {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no sprite for %s", r.PathValue("name")))
+		return
+	}
+	body, contentType, err := fetchAsset(s.c, url)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "max-age=3600")
+	w.Write(body)
+}
+
+func (s *server) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/areas", s.handleAreas)
+	mux.HandleFunc("GET /api/areas/{name}", s.handleExplore)
+	mux.HandleFunc("POST /api/areas/{name}/travel", s.handleTravel)
+	mux.HandleFunc("POST /api/pokemon/{name}/catch", s.handleCatch)
+	mux.HandleFunc("GET /api/pokedex", s.handlePokedex)
+	mux.HandleFunc("GET /api/pokedex/{name}", s.handleInspect)
+	mux.HandleFunc("GET /api/pokedex/{name}/sprite", s.handleSprite)
+	mux.HandleFunc("GET /api/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPIDocument)
+	})
+	web, _ := fs.Sub(webUI, "web")
+	mux.Handle("GET /", http.FileServerFS(web))
+	return mux
+}
+
+// serve runs the JSON API until the listener fails.
+func serve(configure *config, c *pokecache.Cache, args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	s := &server{configure: configure, c: c}
+	log.Printf("serving the pokedex on http://%s/, its api is documented at /api/openapi.json", *addr)
+	return http.ListenAndServe(*addr, s.routes())
+}
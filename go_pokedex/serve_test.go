@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExploreDoesNotTravel(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c := tradeCache()
+	c.Add(pokeAPI+"location-area/viridian-forest-area", []byte(`{"name":"viridian-forest-area","names":[],"pokemon_encounters":[],"encounter_method_rates":[]}`))
+	s := &server{configure: trainer(t, c, "ash", ""), c: c}
+	s.configure.area = "pallet-town-area"
+	api := httptest.NewServer(s.routes())
+	defer api.Close()
+
+	res, err := http.Get(api.URL + "/api/areas/viridian-forest-area")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || s.configure.area != "pallet-town-area" {
+		t.Errorf("GET area: status %d, trainer moved to %q", res.StatusCode, s.configure.area)
+	}
+
+	res, err = http.Post(api.URL+"/api/areas/viridian-forest-area/travel", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body := struct{ Area string }{}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || body.Area != "viridian-forest-area" || s.configure.area != "viridian-forest-area" {
+		t.Errorf("POST travel: status %d, %+v, trainer in %q", res.StatusCode, body, s.configure.area)
+	}
+}
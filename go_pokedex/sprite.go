@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/png"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// spriteWidth is the widest a sprite is drawn, in terminal columns.
+const spriteWidth = 32
+
+// spriteSelectors maps the --sprite values accepted by inspect to the sprite
+// URL they pick. Missing sprites are empty strings.
+var spriteSelectors = map[string]func(o *OwnedPokemon) string{
+	"front": func(o *OwnedPokemon) string { return o.spriteURL() },
+	"back": func(o *OwnedPokemon) string {
+		if o.Shiny {
+			return o.Sprites.BackShiny
+		}
+		return o.Sprites.BackDefault
+	},
+	"shiny":                     func(o *OwnedPokemon) string { return o.Sprites.FrontShiny },
+	"back-shiny":                func(o *OwnedPokemon) string { return o.Sprites.BackShiny },
+	"artwork":                   func(o *OwnedPokemon) string { return o.Sprites.Other.OfficialArtwork.FrontDefault },
+	"artwork-shiny":             func(o *OwnedPokemon) string { return o.Sprites.Other.OfficialArtwork.FrontShiny },
+	"home":                      func(o *OwnedPokemon) string { return o.Sprites.Other.Home.FrontDefault },
+	"dream-world":               func(o *OwnedPokemon) string { return o.Sprites.Other.DreamWorld.FrontDefault },
+	"showdown":                  func(o *OwnedPokemon) string { return o.Sprites.Other.Showdown.FrontDefault },
+	"gen1-red-blue":             func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationI.RedBlue.FrontDefault },
+	"gen1-yellow":               func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationI.Yellow.FrontDefault },
+	"gen2-crystal":              func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIi.Crystal.FrontDefault },
+	"gen2-gold":                 func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIi.Gold.FrontDefault },
+	"gen2-silver":               func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIi.Silver.FrontDefault },
+	"gen3-emerald":              func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIii.Emerald.FrontDefault },
+	"gen3-firered-leafgreen":    func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIii.FireredLeafgreen.FrontDefault },
+	"gen3-ruby-sapphire":        func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIii.RubySapphire.FrontDefault },
+	"gen4-diamond-pearl":        func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIv.DiamondPearl.FrontDefault },
+	"gen4-heartgold-soulsilver": func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIv.HeartgoldSoulsilver.FrontDefault },
+	"gen4-platinum":             func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationIv.Platinum.FrontDefault },
+	"gen5-black-white":          func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationV.BlackWhite.FrontDefault },
+	"gen6-x-y":                  func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationVi.XY.FrontDefault },
+	"gen6-omegaruby-alphasapphire": func(o *OwnedPokemon) string {
+		return o.Sprites.Versions.GenerationVi.OmegarubyAlphasapphire.FrontDefault
+	},
+	"gen7-ultra-sun-ultra-moon": func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationVii.UltraSunUltraMoon.FrontDefault },
+	"gen7-icons":                func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationVii.Icons.FrontDefault },
+	"gen8-icons":                func(o *OwnedPokemon) string { return o.Sprites.Versions.GenerationViii.Icons.FrontDefault },
+}
+
+func spriteSelectorNames() string {
+	names := make([]string, 0, len(spriteSelectors))
+	for name := range spriteSelectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
+
+type colorMode int
+
+const (
+	colorNone colorMode = iota
+	color256
+	colorTrue
+)
+
+// terminalColors works out what the terminal can show: nothing when stdout
+// is not a terminal, NO_COLOR is set or TERM is dumb, truecolor when
+// COLORTERM says so, and 256 colors otherwise.
+func terminalColors() colorMode {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return colorNone
+	}
+	if info, err := os.Stdout.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return colorNone
+	}
+	switch os.Getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return colorTrue
+	}
+	return color256
+}
+
+// opaqueBounds crops away the transparent border sprites are padded with.
+func opaqueBounds(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	box := image.Rectangle{Min: b.Max, Max: b.Min}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a > 0x7fff {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if box.Empty() {
+		return b
+	}
+	return box
+}
+
+func ansiColor(mode colorMode, c color.Color, background bool) string {
+	r, g, b, _ := c.RGBA()
+	r, g, b = r>>8, g>>8, b>>8
+	layer := 38
+	if background {
+		layer = 48
+	}
+	if mode == colorTrue {
+		return fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", layer, r, g, b)
+	}
+	cube := 16 + 36*((r*5+127)/255) + 6*((g*5+127)/255) + (b*5+127)/255
+	return fmt.Sprintf("\x1b[%d;5;%dm", layer, cube)
+}
+
+// asciiShade is used when the terminal has no colors.
+func asciiShade(c color.Color) byte {
+	const ramp = "@%#*+=-:."
+	r, g, b, _ := c.RGBA()
+	lum := (299*r + 587*g + 114*b) / 1000
+	return ramp[int(lum)*(len(ramp)-1)/0xffff]
+}
+
+// renderSprite draws an image with half blocks, two pixels per cell, and
+// returns one string per terminal row. Every row is width cells wide.
+func renderSprite(data []byte, mode colorMode) ([]string, int, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, err
+	}
+	box := opaqueBounds(img)
+	width := box.Dx()
+	if width > spriteWidth {
+		width = spriteWidth
+	}
+	scale := float64(box.Dx()) / float64(width)
+	height := int(float64(box.Dy()) / scale)
+	pixel := func(x, y int) (color.Color, bool) {
+		if y >= height {
+			return nil, false
+		}
+		c := img.At(box.Min.X+int(float64(x)*scale), box.Min.Y+int(float64(y)*scale))
+		_, _, _, a := c.RGBA()
+		return c, a > 0x7fff
+	}
+
+	lines := make([]string, 0, (height+1)/2)
+	for y := 0; y < height; y += 2 {
+		var line strings.Builder
+		for x := 0; x < width; x++ {
+			top, topOK := pixel(x, y)
+			bottom, bottomOK := pixel(x, y+1)
+			switch {
+			case mode == colorNone && topOK:
+				line.WriteByte(asciiShade(top))
+			case mode == colorNone && bottomOK:
+				line.WriteByte(asciiShade(bottom))
+			case topOK && bottomOK:
+				line.WriteString(ansiColor(mode, top, false) + ansiColor(mode, bottom, true) + "▀\x1b[0m")
+			case topOK:
+				line.WriteString(ansiColor(mode, top, false) + "▀\x1b[0m")
+			case bottomOK:
+				line.WriteString(ansiColor(mode, bottom, false) + "▄\x1b[0m")
+			default:
+				line.WriteByte(' ')
+			}
+		}
+		lines = append(lines, line.String())
+	}
+	return lines, width, nil
+}
+
+// loadSprite downloads and renders the selected sprite of an owned Pokemon.
+func loadSprite(c *pokecache.Cache, o *OwnedPokemon, selector string) ([]string, int, error) {
+	pick, ok := spriteSelectors[selector]
+	if !ok {
+		return nil, 0, fmt.Errorf("unknown sprite %q, pick one of %s", selector, spriteSelectorNames())
+	}
+	url := pick(o)
+	if url == "" {
+		return nil, 0, fmt.Errorf("%s has no %s sprite", o.Name, selector)
+	}
+	data, _, err := fetchAsset(c, url)
+	if err != nil {
+		return nil, 0, err
+	}
+	return renderSprite(data, terminalColors())
+}
+
+// printBeside prints the sprite rows on the left and the text on the right.
+func printBeside(art []string, width int, text []string) {
+	rows := len(art)
+	if len(text) > rows {
+		rows = len(text)
+	}
+	blank := strings.Repeat(" ", width)
+	gap := "  "
+	if width == 0 {
+		gap = ""
+	}
+	for i := 0; i < rows; i++ {
+		left, right := blank, ""
+		if i < len(art) {
+			left = art[i]
+		}
+		if i < len(text) {
+			right = text[i]
+		}
+		fmt.Println(strings.TrimRight(left+gap+right, " "))
+	}
+}
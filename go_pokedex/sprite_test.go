@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// spritePNG draws a sprite with a transparent border: a red column three
+// pixels tall with a blue pixel to the right of its top.
+func spritePNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, 6, 7))
+	red := color.NRGBA{255, 0, 0, 255}
+	for y := 2; y < 5; y++ {
+		img.Set(2, y, red)
+	}
+	img.Set(3, 2, color.NRGBA{0, 0, 255, 255})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRenderSprite(t *testing.T) {
+	data := spritePNG(t)
+	const (
+		redFG  = "\x1b[38;2;255;0;0m"
+		redBG  = "\x1b[48;2;255;0;0m"
+		blueFG = "\x1b[38;2;0;0;255m"
+		reset  = "\x1b[0m"
+	)
+	cases := []struct {
+		mode colorMode
+		want []string
+	}{
+		{colorNone, []string{"#@", "# "}},
+		{colorTrue, []string{redFG + redBG + "▀" + reset + blueFG + "▀" + reset, redFG + "▀" + reset + " "}},
+		{color256, []string{"\x1b[38;5;196m\x1b[48;5;196m▀" + reset + "\x1b[38;5;21m▀" + reset, "\x1b[38;5;196m▀" + reset + " "}},
+	}
+	for _, tc := range cases {
+		rows, width, err := renderSprite(data, tc.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if width != 2 {
+			t.Errorf("mode %d: width = %d, want the 2 opaque columns", tc.mode, width)
+		}
+		if !reflect.DeepEqual(rows, tc.want) {
+			t.Errorf("mode %d: rows = %q, want %q", tc.mode, rows, tc.want)
+		}
+	}
+}
+
+func TestRenderSpriteScalesWideImages(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4*spriteWidth, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 4*spriteWidth; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	rows, width, err := renderSprite(buf.Bytes(), colorNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if width != spriteWidth || len(rows) != 1 || len(rows[0]) != spriteWidth {
+		t.Errorf("rendered %d rows %d wide, want 1 row %d wide", len(rows), width, spriteWidth)
+	}
+	if _, _, err := renderSprite([]byte("not an image"), colorNone); err == nil {
+		t.Error("rendering garbage did not fail")
+	}
+}
+
+func spritedPokemon(t *testing.T) *OwnedPokemon {
+	t.Helper()
+	mon := &OwnedPokemon{}
+	raw := `{"name":"pikachu","sprites":{
+		"front_default":"front.png","front_shiny":"front-shiny.png","back_default":"back.png","back_shiny":"back-shiny.png",
+		"front_female":"front-female.png","front_shiny_female":null,
+		"other":{"official-artwork":{"front_default":"artwork.png"}},
+		"versions":{"generation-i":{"red-blue":{"front_default":"red-blue.png"}}}}}`
+	if err := json.Unmarshal([]byte(raw), &mon.Pokemon); err != nil {
+		t.Fatal(err)
+	}
+	return mon
+}
+
+func TestSpriteVariants(t *testing.T) {
+	variants := make(map[string]string)
+	spriteVariants(reflect.ValueOf(spritedPokemon(t).Sprites), "", variants)
+	want := map[string]string{
+		"front_default":                        "front.png",
+		"front_shiny":                          "front-shiny.png",
+		"back_default":                         "back.png",
+		"back_shiny":                           "back-shiny.png",
+		"front_female":                         "front-female.png",
+		"other/official-artwork/front_default": "artwork.png",
+		"versions/generation-i/red-blue/front_default": "red-blue.png",
+	}
+	if !reflect.DeepEqual(variants, want) {
+		t.Errorf("spriteVariants = %v, want %v", variants, want)
+	}
+}
+
+func TestSpriteSelectors(t *testing.T) {
+	mon := spritedPokemon(t)
+	cases := []struct {
+		gender   string
+		shiny    bool
+		selector string
+		want     string
+	}{
+		{"male", false, "front", "front.png"},
+		{"male", true, "front", "front-shiny.png"},
+		{"female", false, "front", "front-female.png"},
+		{"female", true, "front", "front-shiny.png"}, // no shiny female sprite
+		{"male", false, "back", "back.png"},
+		{"male", true, "back", "back-shiny.png"},
+		{"male", false, "artwork", "artwork.png"},
+		{"male", false, "gen1-red-blue", "red-blue.png"},
+		{"male", false, "home", ""},
+	}
+	for _, tc := range cases {
+		mon.Gender, mon.Shiny = tc.gender, tc.shiny
+		if got := spriteSelectors[tc.selector](mon); got != tc.want {
+			t.Errorf("%s shiny=%v %s = %q, want %q", tc.gender, tc.shiny, tc.selector, got, tc.want)
+		}
+	}
+
+	if _, _, err := loadSprite(nil, mon, "sideways"); err == nil || !strings.Contains(err.Error(), "unknown sprite") {
+		t.Errorf("unknown selector: %v", err)
+	}
+	if _, _, err := loadSprite(nil, mon, "home"); err == nil || !strings.Contains(err.Error(), "no home sprite") {
+		t.Errorf("missing sprite: %v", err)
+	}
+}
+
+func TestAssetExtension(t *testing.T) {
+	cases := []struct {
+		contentType, url, want string
+	}{
+		{"image/png", "https://example.com/25", ".png"},
+		{"image/gif", "https://example.com/25.png", ".gif"},
+		{"image/svg+xml", "https://example.com/25", ".svg"},
+		{"", "https://example.com/sprites/25.png", ".png"},
+		{"application/x-unknown-sprite", "https://example.com/25.gif", ".gif"},
+	}
+	for _, tc := range cases {
+		if got := assetExtension(tc.contentType, tc.url); got != tc.want {
+			t.Errorf("assetExtension(%q, %q) = %q, want %q", tc.contentType, tc.url, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// statNames lists the six stats in the order the games display them.
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+const (
+	maxIV       = 31
+	maxStatEV   = 252
+	maxTotalEVs = 510
+	shinyOdds   = 4096
+)
+
+// OwnedPokemon is a caught Pokemon together with the values rolled for that
+// individual when it was caught.
+type OwnedPokemon struct {
+	Pokemon
+	Level  int            `json:"level"`
+	Nature string         `json:"nature"`
+	IVs    map[string]int `json:"ivs"`
+	EVs    map[string]int `json:"evs"`
+
+	// KnownMoves holds at most four move names usable in battle.
+	KnownMoves []string `json:"known_moves"`
+
+	Exp        int    `json:"exp"`
+	GrowthRate string `json:"growth_rate"`
+	Friendship int    `json:"friendship"`
+
+	Shiny  bool   `json:"shiny"`
+	Gender string `json:"gender"` // "male", "female" or "genderless"
+
+	// UID tells apart individuals of the same species across trainers.
+	UID string `json:"uid"`
+
+	CaughtAt time.Time `json:"caught_at"`
+	CaughtIn string    `json:"caught_in"` // location area, empty when unknown
+}
+
+type nature struct {
+	name string
+	up   string // stat raised by 10%
+	down string // stat lowered by 10%
+}
+
+// natures where up and down are the same stat are neutral.
+var natures = []nature{
+	{"hardy", "attack", "attack"},
+	{"lonely", "attack", "defense"},
+	{"brave", "attack", "speed"},
+	{"adamant", "attack", "special-attack"},
+	{"naughty", "attack", "special-defense"},
+	{"bold", "defense", "attack"},
+	{"docile", "defense", "defense"},
+	{"relaxed", "defense", "speed"},
+	{"impish", "defense", "special-attack"},
+	{"lax", "defense", "special-defense"},
+	{"timid", "speed", "attack"},
+	{"hasty", "speed", "defense"},
+	{"serious", "speed", "speed"},
+	{"jolly", "speed", "special-attack"},
+	{"naive", "speed", "special-defense"},
+	{"modest", "special-attack", "attack"},
+	{"mild", "special-attack", "defense"},
+	{"quiet", "special-attack", "speed"},
+	{"bashful", "special-attack", "special-attack"},
+	{"rash", "special-attack", "special-defense"},
+	{"calm", "special-defense", "attack"},
+	{"gentle", "special-defense", "defense"},
+	{"sassy", "special-defense", "speed"},
+	{"careful", "special-defense", "special-attack"},
+	{"quirky", "special-defense", "special-defense"},
+}
+
+func findNature(name string) (nature, bool) {
+	for _, n := range natures {
+		if n.name == name {
+			return n, true
+		}
+	}
+	return nature{}, false
+}
+
+// newOwnedPokemon rolls the individual values for a freshly caught Pokemon.
+// EVs start at zero and are earned from the Effort yields of defeated Pokemon.
+// Moves come from the learnset of the filter's version group.
+func newOwnedPokemon(rng *rand.Rand, poke Pokemon, level int, filter versionFilter) *OwnedPokemon {
+	owned := &OwnedPokemon{
+		Pokemon: poke,
+		Level:   level,
+		Nature:  natures[rng.Intn(len(natures))].name,
+		IVs:     make(map[string]int),
+		EVs:     make(map[string]int),
+	}
+	for _, name := range statNames {
+		owned.IVs[name] = rng.Intn(maxIV + 1)
+		owned.EVs[name] = 0
+	}
+	owned.KnownMoves = owned.defaultMoves(filter)
+	owned.Shiny = rng.Intn(shinyOdds) == 0
+	owned.UID = newUID()
+	return owned
+}
+
+// rollGender picks a gender from the species gender_rate.
+func rollGender(rng *rand.Rand, genderRate int) string {
+	if genderRate < 0 {
+		return "genderless"
+	}
+	if rng.Intn(8) < genderRate {
+		return "female"
+	}
+	return "male"
+}
+
+func (o *OwnedPokemon) genderSymbol() string {
+	switch o.Gender {
+	case "male":
+		return "♂"
+	case "female":
+		return "♀"
+	}
+	return ""
+}
+
+// spriteURL picks the front sprite matching the Pokemon's shininess and
+// gender, falling back to the default sprite.
+func (o *OwnedPokemon) spriteURL() string {
+	s := o.Sprites
+	if o.Gender == "female" {
+		female := s.FrontFemale
+		if o.Shiny {
+			female = s.FrontShinyFemale
+		}
+		if url, ok := female.(string); ok && url != "" {
+			return url
+		}
+	}
+	if o.Shiny && s.FrontShiny != "" {
+		return s.FrontShiny
+	}
+	return s.FrontDefault
+}
+
+// catchLevel picks the level of a wild Pokemon when nothing better is known.
+func catchLevel(rng *rand.Rand) int {
+	return rng.Intn(21) + 5
+}
+
+// levelUpMoves maps every move learnt by levelling up to the lowest level it
+// is learnt at in the filter's version group, or in any version group when
+// the filter has none or the Pokemon has no learnset for it.
+func (p Pokemon) levelUpMoves(filter versionFilter) map[string]int {
+	learnt := make(map[string]int)
+	for _, m := range p.Moves {
+		for _, d := range m.VersionGroupDetails {
+			if d.MoveLearnMethod.Name != "level-up" {
+				continue
+			}
+			if !filter.matchGroup(d.VersionGroup.Name) {
+				continue
+			}
+			if lvl, ok := learnt[m.Move.Name]; !ok || d.LevelLearnedAt < lvl {
+				learnt[m.Move.Name] = d.LevelLearnedAt
+			}
+		}
+	}
+	if filter.Group != "" && len(learnt) == 0 {
+		return p.levelUpMoves(versionFilter{})
+	}
+	return learnt
+}
+
+// defaultMoves returns the last four level-up moves learnt at or below the
+// current level, the same way the games fill in a wild Pokemon's moves.
+func (o *OwnedPokemon) defaultMoves(filter versionFilter) []string {
+	type learnt struct {
+		name  string
+		level int
+	}
+	moves := make([]learnt, 0)
+	for name, lvl := range o.levelUpMoves(filter) {
+		if lvl <= o.Level {
+			moves = append(moves, learnt{name, lvl})
+		}
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i].level != moves[j].level {
+			return moves[i].level < moves[j].level
+		}
+		return moves[i].name < moves[j].name
+	})
+	if len(moves) > 4 {
+		moves = moves[len(moves)-4:]
+	}
+	names := make([]string, 0, len(moves))
+	for _, m := range moves {
+		names = append(names, m.name)
+	}
+	return names
+}
+
+func (o *OwnedPokemon) baseStat(name string) int {
+	for _, s := range o.Stats {
+		if s.Stat.Name == name {
+			return s.BaseStat
+		}
+	}
+	return 0
+}
+
+// stat computes the actual value of a stat using the standard formulas:
+//
+//	hp    = (2*base + iv + ev/4) * level/100 + level + 10
+//	other = ((2*base + iv + ev/4) * level/100 + 5) * nature
+func (o *OwnedPokemon) stat(name string) int {
+	v := (2*o.baseStat(name) + o.IVs[name] + o.EVs[name]/4) * o.Level / 100
+	if name == "hp" {
+		return v + o.Level + 10
+	}
+	v += 5
+	if n, ok := findNature(o.Nature); ok && n.up != n.down {
+		switch name {
+		case n.up:
+			v = v * 110 / 100
+		case n.down:
+			v = v * 90 / 100
+		}
+	}
+	return v
+}
+
+func (o *OwnedPokemon) totalEVs() int {
+	total := 0
+	for _, v := range o.EVs {
+		total += v
+	}
+	return total
+}
+
+// gainEffort adds the Effort yields of a defeated Pokemon to the EVs,
+// respecting the per-stat and total caps.
+func (o *OwnedPokemon) gainEffort(defeated Pokemon) {
+	for _, s := range defeated.Stats {
+		gain := s.Effort
+		if room := maxStatEV - o.EVs[s.Stat.Name]; gain > room {
+			gain = room
+		}
+		if room := maxTotalEVs - o.totalEVs(); gain > room {
+			gain = room
+		}
+		if gain > 0 {
+			o.EVs[s.Stat.Name] += gain
+		}
+	}
+}
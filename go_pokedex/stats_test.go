@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// testPokemon builds a Pokemon the way it comes out of the API, with the
+// given types and base stats in statNames order.
+func testPokemon(t *testing.T, name string, types []string, base [6]int) Pokemon {
+	t.Helper()
+	stats := make([]string, 0, len(base))
+	for i, s := range statNames {
+		stats = append(stats, fmt.Sprintf(`{"base_stat":%d,"effort":0,"stat":{"name":%q}}`, base[i], s))
+	}
+	slots := make([]string, 0, len(types))
+	for i, typ := range types {
+		slots = append(slots, fmt.Sprintf(`{"slot":%d,"type":{"name":%q}}`, i+1, typ))
+	}
+	raw := fmt.Sprintf(`{"name":%q,"stats":[%s],"types":[%s]}`, name, strings.Join(stats, ","), strings.Join(slots, ","))
+	poke := Pokemon{}
+	if err := json.Unmarshal([]byte(raw), &poke); err != nil {
+		t.Fatal(err)
+	}
+	return poke
+}
+
+func TestOwnedPokemonStat(t *testing.T) {
+	garchomp := &OwnedPokemon{
+		Pokemon: testPokemon(t, "garchomp", []string{"dragon", "ground"}, [6]int{108, 130, 95, 80, 85, 102}),
+		Level:   78,
+		Nature:  "adamant",
+		IVs:     map[string]int{"hp": 24, "attack": 12, "defense": 30, "special-attack": 16, "special-defense": 23, "speed": 5},
+		EVs:     map[string]int{"hp": 74, "attack": 195, "defense": 86, "special-attack": 48, "special-defense": 84, "speed": 23},
+	}
+	cases := []struct {
+		mon  *OwnedPokemon
+		stat string
+		want int
+	}{
+		{garchomp, "hp", 289},
+		{garchomp, "attack", 279},          // raised by adamant
+		{garchomp, "defense", 192},         // neutral
+		{garchomp, "special-attack", 135},  // lowered by adamant
+		{garchomp, "special-defense", 171}, // neutral
+		{garchomp, "speed", 171},
+		{&OwnedPokemon{Pokemon: garchomp.Pokemon, Level: 1, Nature: "hardy"}, "hp", 13},
+		{&OwnedPokemon{Pokemon: garchomp.Pokemon, Level: 1, Nature: "hardy"}, "attack", 7},
+		{&OwnedPokemon{Pokemon: garchomp.Pokemon, Level: 100, Nature: "serious"}, "speed", 209},
+	}
+	for _, tc := range cases {
+		if got := tc.mon.stat(tc.stat); got != tc.want {
+			t.Errorf("Lv%d %s %s = %d, want %d", tc.mon.Level, tc.mon.Nature, tc.stat, got, tc.want)
+		}
+	}
+}
+
+func TestNewOwnedPokemon(t *testing.T) {
+	poke := testPokemon(t, "pikachu", []string{"electric"}, [6]int{35, 55, 40, 50, 50, 90})
+	for i := 0; i < 100; i++ {
+		mon := newOwnedPokemon(rand.New(rand.NewSource(int64(i))), poke, 5, versionFilter{})
+		if _, ok := findNature(mon.Nature); !ok {
+			t.Fatalf("rolled unknown nature %q", mon.Nature)
+		}
+		for _, stat := range statNames {
+			if iv := mon.IVs[stat]; iv < 0 || iv > maxIV {
+				t.Fatalf("rolled %s IV %d, want 0-%d", stat, iv, maxIV)
+			}
+			if ev := mon.EVs[stat]; ev != 0 {
+				t.Fatalf("new pokemon has %d %s EVs, want 0", ev, stat)
+			}
+		}
+	}
+}
+
+func TestGainEffort(t *testing.T) {
+	defeated := testPokemon(t, "machamp", []string{"fighting"}, [6]int{90, 130, 80, 65, 85, 55})
+	for i := range defeated.Stats {
+		if defeated.Stats[i].Stat.Name == "attack" {
+			defeated.Stats[i].Effort = 3
+		}
+	}
+	mon := &OwnedPokemon{EVs: map[string]int{"attack": 250, "hp": 252, "defense": 6}}
+	mon.gainEffort(defeated)
+	if got := mon.EVs["attack"]; got != maxStatEV {
+		t.Errorf("attack EVs = %d, want the per-stat cap %d", got, maxStatEV)
+	}
+
+	mon = &OwnedPokemon{EVs: map[string]int{"attack": 0, "hp": 252, "defense": 252, "speed": 5}}
+	mon.gainEffort(defeated)
+	if got := mon.totalEVs(); got != maxTotalEVs {
+		t.Errorf("total EVs = %d, want the total cap %d", got, maxTotalEVs)
+	}
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// tradeTimeout bounds how long we wait for the other trainer at each step.
+const tradeTimeout = 5 * time.Minute
+
+// tradeMessage is one line of the trade protocol. Both sides send, in order:
+// hello, offer (or cancel), answer, and commit once both answers accepted.
+// When either hello names a pending trade, settle is sent instead of offer.
+type tradeMessage struct {
+	Type      string        `json:"type"`
+	Trainer   string        `json:"trainer,omitempty"`
+	Nonce     string        `json:"nonce,omitempty"`
+	Pending   string        `json:"pending,omitempty"`
+	Pokemon   *OwnedPokemon `json:"pokemon,omitempty"`
+	Accept    bool          `json:"accept,omitempty"`
+	Completed bool          `json:"completed,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+}
+
+// tradeRecord is one completed trade in the trainer's history.
+type tradeRecord struct {
+	ID       string    `json:"id,omitempty"`
+	Time     time.Time `json:"time"`
+	Partner  string    `json:"partner"`
+	Gave     string    `json:"gave"`
+	Received string    `json:"received"`
+}
+
+// pendingTrade is saved in the profile once both trainers accepted, before
+// the commits are exchanged. If the connection drops in between, neither
+// side knows whether the other completed the trade; the next trade between
+// the two trainers settles it from both sides' records before anything else.
+type pendingTrade struct {
+	ID       string        `json:"id"`
+	Partner  string        `json:"partner"`
+	Gave     string        `json:"gave"` // UID of the Pokemon given away
+	Received *OwnedPokemon `json:"received"`
+}
+
+// tradeID names a trade after the random nonces both sides sent in hello.
+func tradeID(a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + "-" + b
+}
+
+func tradeCompleted(configure *config, id string) bool {
+	for _, t := range configure.trades {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// checkReceived vets a Pokemon coming from another trainer before it enters
+// the pokedex. The Pokemon data is fetched again by name instead of trusted,
+// the individual values are clamped to what the games allow and missing maps
+// are filled in. Being shiny cannot be checked and is taken as sent.
+func checkReceived(c *pokecache.Cache, o *OwnedPokemon) error {
+	if !slugPattern.MatchString(o.Name) {
+		return fmt.Errorf("%q is not a pokemon name", o.Name)
+	}
+	poke, err := fetchPokemon(c, o.Name)
+	if err == errNotFound {
+		return fmt.Errorf("there is no pokemon named %s", o.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to check %s: %v", o.Name, err)
+	}
+	if poke.ID != o.ID {
+		return fmt.Errorf("%s does not match its pokemon data", o.Name)
+	}
+	species, err := fetchSpecies(c, poke.Species.URL)
+	if err != nil {
+		return fmt.Errorf("unable to check %s: %v", o.Name, err)
+	}
+	o.Pokemon = poke
+	o.GrowthRate = species.GrowthRate.Name
+	o.Level = min(max(o.Level, 1), maxLevel)
+	o.Exp = max(o.Exp, expForLevel(o.GrowthRate, o.Level))
+	if o.Level < maxLevel {
+		o.Exp = min(o.Exp, expForLevel(o.GrowthRate, o.Level+1)-1)
+	}
+	o.Friendship = min(max(o.Friendship, 0), maxFriendship)
+	if _, ok := findNature(o.Nature); !ok {
+		return fmt.Errorf("%s has an unknown nature %q", o.Name, o.Nature)
+	}
+	switch {
+	case species.GenderRate < 0 && o.Gender != "genderless",
+		species.GenderRate == 0 && o.Gender != "male",
+		species.GenderRate == 8 && o.Gender != "female",
+		species.GenderRate > 0 && species.GenderRate < 8 && o.Gender != "male" && o.Gender != "female":
+		return fmt.Errorf("%s cannot be %q", o.Name, o.Gender)
+	}
+
+	ivs, evs := make(map[string]int), make(map[string]int)
+	total := 0
+	for _, stat := range statNames {
+		ivs[stat] = min(max(o.IVs[stat], 0), maxIV)
+		evs[stat] = min(max(o.EVs[stat], 0), maxStatEV, maxTotalEVs-total)
+		total += evs[stat]
+	}
+	o.IVs, o.EVs = ivs, evs
+
+	learnable := make(map[string]bool, len(poke.Moves))
+	for _, m := range poke.Moves {
+		learnable[m.Move.Name] = true
+	}
+	known := make([]string, 0, 4)
+	for _, move := range o.KnownMoves {
+		if learnable[move] && !slices.Contains(known, move) && len(known) < 4 {
+			known = append(known, move)
+		}
+	}
+	o.KnownMoves = known
+
+	if !slugPattern.MatchString(o.UID) {
+		o.UID = newUID()
+	}
+	if !slugPattern.MatchString(o.CaughtIn) {
+		o.CaughtIn = ""
+	}
+	return nil
+}
+
+// tradeAddress reads unix:/path as a Unix socket and anything else as a TCP
+// host:port.
+func tradeAddress(addr string) (string, string) {
+	if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+		return "unix", path
+	}
+	return "tcp", addr
+}
+
+type tradeConn struct {
+	conn net.Conn
+	enc  *json.Encoder
+	dec  *json.Decoder
+}
+
+func newTradeConn(conn net.Conn) *tradeConn {
+	return &tradeConn{conn: conn, enc: json.NewEncoder(conn), dec: json.NewDecoder(conn)}
+}
+
+func (t *tradeConn) send(m tradeMessage) error {
+	t.conn.SetWriteDeadline(time.Now().Add(tradeTimeout))
+	return t.enc.Encode(m)
+}
+
+// receive reads the next message, which must be of type want or a cancel.
+func (t *tradeConn) receive(want string) (tradeMessage, error) {
+	t.conn.SetReadDeadline(time.Now().Add(tradeTimeout))
+	m := tradeMessage{}
+	if err := t.dec.Decode(&m); err != nil {
+		return m, fmt.Errorf("lost the other trainer: %v", err)
+	}
+	if m.Type == "cancel" {
+		return m, fmt.Errorf("the other trainer cancelled the trade")
+	}
+	if m.Type != want {
+		return m, fmt.Errorf("unexpected %q message from the other trainer", m.Type)
+	}
+	return m, nil
+}
+
+func trainerName(configure *config) string {
+	if configure.profile != "" {
+		return configure.profile
+	}
+	return "trainer"
+}
+
+// runTrade walks both trainers through offering, confirming and committing
+// a trade. Nothing changes unless both sides accepted and committed; a trade
+// cut off while committing is settled the next time the trainers connect.
+func runTrade(configure *config, c *pokecache.Cache, t *tradeConn) error {
+	hello := tradeMessage{Type: "hello", Trainer: trainerName(configure), Nonce: newUID()}
+	if configure.pending != nil {
+		hello.Pending = configure.pending.ID
+	}
+	if err := t.send(hello); err != nil {
+		return err
+	}
+	theirHello, err := t.receive("hello")
+	if err != nil {
+		return err
+	}
+	partner := theirHello.Trainer
+	fmt.Printf(configure.tr("connected to %s")+"\n", partner)
+	if configure.pending != nil || theirHello.Pending != "" {
+		return settleTrade(configure, c, t, theirHello)
+	}
+
+	var mine *OwnedPokemon
+	for mine == nil {
+		name := prompt(configure, configure.tr("offer which pokemon? (empty to cancel) > "))
+		if name == "" {
+			t.send(tradeMessage{Type: "cancel"})
+			return fmt.Errorf("trade cancelled")
+		}
+		if owned, exists := configure.caughtPokemon[name]; exists {
+			mine = owned
+		} else {
+			fmt.Println(configure.tr("you have not caught that pokemon") + notCaughtHint(configure, c, name))
+		}
+	}
+	if err := t.send(tradeMessage{Type: "offer", Pokemon: mine}); err != nil {
+		return err
+	}
+	fmt.Printf(configure.tr("waiting for %s's offer...")+"\n", partner)
+	offer, err := t.receive("offer")
+	if err != nil {
+		return err
+	}
+	theirs := offer.Pokemon
+	if theirs == nil || theirs.Name == "" {
+		t.send(tradeMessage{Type: "cancel"})
+		return fmt.Errorf("%s offered nothing", partner)
+	}
+	if err := checkReceived(c, theirs); err != nil {
+		t.send(tradeMessage{Type: "cancel"})
+		return fmt.Errorf("%s's offer was refused: %v", partner, err)
+	}
+
+	answer := tradeMessage{Type: "answer"}
+	if _, exists := configure.caughtPokemon[theirs.Name]; exists && theirs.Name != mine.Name {
+		answer.Reason = "already has a " + theirs.Name
+		fmt.Printf(configure.tr("%s offers %s, but you already have one")+"\n", partner, configure.pokemonName(c, theirs.Name))
+	} else {
+		fmt.Printf(configure.tr("%s offers %s, a level %d %s %s")+"\n", partner, configure.pokemonName(c, theirs.Name), theirs.Level,
+			configure.localize(c, "nature", theirs.Nature), configure.tr(theirs.Gender))
+		reply := prompt(configure, fmt.Sprintf(configure.tr("trade your %s for it? (y/n) > "), configure.pokemonName(c, mine.Name)))
+		answer.Accept = reply == "y" || reply == "yes"
+		if !answer.Accept {
+			answer.Reason = "declined"
+		}
+	}
+	if err := t.send(answer); err != nil {
+		return err
+	}
+	theirAnswer, err := t.receive("answer")
+	if err != nil {
+		return err
+	}
+	if !answer.Accept {
+		return fmt.Errorf("trade called off")
+	}
+	if !theirAnswer.Accept {
+		return fmt.Errorf("%s did not accept: %s", partner, theirAnswer.Reason)
+	}
+
+	// write down the trade before committing, so that whatever happens to
+	// the connection it can be settled later
+	configure.pending = &pendingTrade{ID: tradeID(hello.Nonce, theirHello.Nonce), Partner: partner, Gave: mine.UID, Received: theirs}
+	if err := saveProfile(configure); err != nil {
+		configure.pending = nil
+		t.send(tradeMessage{Type: "cancel"})
+		return fmt.Errorf("trade called off, unable to save it: %v", err)
+	}
+	if err := t.send(tradeMessage{Type: "commit"}); err != nil {
+		return unfinishedTrade(partner, err)
+	}
+	if commit, err := t.receive("commit"); err != nil {
+		if commit.Type == "cancel" {
+			// the other side could not write the trade down and never commits
+			return callOffTrade(configure, err)
+		}
+		return unfinishedTrade(partner, err)
+	}
+	return completeTrade(configure, c)
+}
+
+func unfinishedTrade(partner string, err error) error {
+	return fmt.Errorf("%v; the trade may not have finished, trade with %s again to settle it", err, partner)
+}
+
+// callOffTrade drops the pending trade, keeping the Pokemon that was offered.
+func callOffTrade(configure *config, reason error) error {
+	configure.pending = nil
+	if err := saveProfile(configure); err != nil {
+		return err
+	}
+	return reason
+}
+
+// settleTrade finishes or calls off a trade that was cut off while the two
+// trainers committed it. Each side says whether it finished the other's
+// pending trade: a trade finished by one side, or pending on both, is
+// finished; a trade the other side has no record of never reached its
+// commit and is called off.
+func settleTrade(configure *config, c *pokecache.Cache, t *tradeConn, theirHello tradeMessage) error {
+	partner := theirHello.Trainer
+	p := configure.pending
+	if p != nil && p.Partner != partner {
+		t.send(tradeMessage{Type: "cancel", Reason: "unfinished trade with another trainer"})
+		return fmt.Errorf("finish your interrupted trade with %s first, or give it up with trade abandon", p.Partner)
+	}
+	settle := tradeMessage{Type: "settle", Completed: theirHello.Pending != "" && tradeCompleted(configure, theirHello.Pending)}
+	if err := t.send(settle); err != nil {
+		return err
+	}
+	theirSettle, err := t.receive("settle")
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		fmt.Printf(configure.tr("settled %s's interrupted trade, you can trade again now")+"\n", partner)
+		return nil
+	}
+	if theirSettle.Completed || theirHello.Pending == p.ID {
+		fmt.Printf(configure.tr("finishing the trade with %s that was interrupted")+"\n", partner)
+		return completeTrade(configure, c)
+	}
+	fmt.Printf(configure.tr("the interrupted trade with %s never went through, nothing changed")+"\n", partner)
+	return callOffTrade(configure, nil)
+}
+
+// completeTrade carries out the pending trade: the Pokemon given away leaves,
+// the one received takes its place, and the profile is saved in one write.
+func completeTrade(configure *config, c *pokecache.Cache) error {
+	p := configure.pending
+	theirs := p.Received
+	gave := "?"
+	if mine, exists := findOwned(configure, p.Gave); exists {
+		gave = mine.Name
+		delete(configure.caughtPokemon, mine.Name)
+	}
+	configure.caughtPokemon[theirs.Name] = theirs
+	inParty := false
+	for i, name := range configure.party {
+		if name == gave {
+			configure.party[i] = theirs.Name
+			inParty = true
+		}
+	}
+	if !inParty && len(configure.party) < 6 {
+		configure.party = append(configure.party, theirs.Name)
+	}
+	configure.trades = append(configure.trades, tradeRecord{ID: p.ID, Time: time.Now(), Partner: p.Partner, Gave: gave, Received: theirs.Name})
+	configure.pending = nil
+	if err := saveProfile(configure); err != nil {
+		return fmt.Errorf("the trade went through but could not be saved: %v", err)
+	}
+	fmt.Printf(configure.tr("you sent %s to %s and received %s!")+"\n", configure.pokemonName(c, gave), p.Partner, configure.pokemonName(c, theirs.Name))
+	if _, err := tryEvolve(configure, c, theirs, "trade", "", os.Stdout); err != nil {
+		return err
+	}
+	return nil
+}
+
+func printTradeHistory(configure *config, c *pokecache.Cache) {
+	if len(configure.trades) == 0 {
+		fmt.Println(configure.tr("no trades yet"))
+		return
+	}
+	for _, t := range configure.trades {
+		fmt.Printf("%s  "+configure.tr("gave %s to %s for %s")+"\n", t.Time.Format("2006-01-02 15:04"),
+			configure.pokemonName(c, t.Gave), t.Partner, configure.pokemonName(c, t.Received))
+	}
+}
+
+func commandTrade(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) == 1 && args[0] == "history" {
+		printTradeHistory(configure, c)
+		return nil
+	}
+	if len(args) == 1 && args[0] == "abandon" {
+		if configure.pending == nil {
+			fmt.Println(configure.tr("no interrupted trade to give up"))
+			return nil
+		}
+		p := configure.pending
+		answer := prompt(configure, fmt.Sprintf(configure.tr("give up the interrupted trade with %s? if they finished it, both of you keep %s (y/n) > "), p.Partner, configure.pokemonName(c, p.Received.Name)))
+		if answer != "y" && answer != "yes" {
+			return nil
+		}
+		return callOffTrade(configure, nil)
+	}
+	if len(args) != 2 {
+		return usageError(configure, "trade")
+	}
+	network, address := tradeAddress(args[1])
+	var conn net.Conn
+	switch args[0] {
+	case "host":
+		listener, err := net.Listen(network, address)
+		if err != nil {
+			return err
+		}
+		fmt.Printf(configure.tr("waiting for a trainer on %s...")+"\n", listener.Addr())
+		conn, err = listener.Accept()
+		listener.Close()
+		if err != nil {
+			return err
+		}
+	case "connect":
+		var err error
+		conn, err = net.DialTimeout(network, address, 10*time.Second)
+		if err != nil {
+			return err
+		}
+	default:
+		return usageError(configure, "trade")
+	}
+	defer conn.Close()
+	return runTrade(configure, c, newTradeConn(conn))
+}
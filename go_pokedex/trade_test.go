@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// tradeCache serves the Pokemon, species and evolution chains the trade
+// tests need; none of them evolves by trade.
+func tradeCache() *pokecache.Cache {
+	c := pokecache.NewCache(time.Hour)
+	for _, p := range []struct {
+		id    int
+		name  string
+		typ   string
+		moves []string
+	}{
+		{25, "pikachu", "electric", []string{"thunder-shock", "growl", "quick-attack"}},
+		{19, "rattata", "normal", []string{"tackle", "tail-whip"}},
+	} {
+		moves := make([]string, 0, len(p.moves))
+		for _, m := range p.moves {
+			moves = append(moves, fmt.Sprintf(`{"move":{"name":%q},"version_group_details":[{"level_learned_at":1,"move_learn_method":{"name":"level-up"},"version_group":{"name":"red-blue"}}]}`, m))
+		}
+		stats := make([]string, 0, len(statNames))
+		for _, s := range statNames {
+			stats = append(stats, fmt.Sprintf(`{"base_stat":50,"effort":1,"stat":{"name":%q}}`, s))
+		}
+		c.Add(pokeAPI+"pokemon/"+p.name, []byte(fmt.Sprintf(
+			`{"id":%d,"name":%q,"base_experience":100,"species":{"name":%[2]q,"url":"%[5]spokemon-species/%[2]s/"},"types":[{"slot":1,"type":{"name":%[3]q}}],"stats":[%[4]s],"moves":[%[6]s]}`,
+			p.id, p.name, p.typ, strings.Join(stats, ","), pokeAPI, strings.Join(moves, ","))))
+		c.Add(pokeAPI+"pokemon-species/"+p.name+"/", []byte(fmt.Sprintf(
+			`{"name":%q,"gender_rate":4,"base_happiness":70,"growth_rate":{"name":"medium"},"evolution_chain":{"url":"%sevolution-chain/%d/"}}`,
+			p.name, pokeAPI, p.id)))
+		c.Add(fmt.Sprintf("%sevolution-chain/%d/", pokeAPI, p.id), []byte(fmt.Sprintf(
+			`{"id":%d,"chain":{"species":{"name":%q},"evolves_to":[]}}`, p.id, p.name)))
+	}
+	return c
+}
+
+// trainer is a profile owning the given Pokemon, answering prompts with input.
+func trainer(t *testing.T, c *pokecache.Cache, profile, input string, owned ...string) *config {
+	t.Helper()
+	configure := &config{
+		caughtPokemon: make(map[string]*OwnedPokemon),
+		inventory:     make(map[string]int),
+		localNames:    make(map[string]string),
+		profile:       profile,
+		rng:           rand.New(rand.NewSource(1)),
+		input:         bufio.NewScanner(strings.NewReader(input)),
+	}
+	for _, name := range owned {
+		poke, err := fetchPokemon(c, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mon := newOwnedPokemon(configure.rng, poke, 10, versionFilter{})
+		if err := mon.loadSpecies(c, configure.rng); err != nil {
+			t.Fatal(err)
+		}
+		configure.caughtPokemon[name] = mon
+		configure.party = append(configure.party, name)
+	}
+	return configure
+}
+
+// tradeOverSocket runs both ends of a trade over a Unix socket, host hosting.
+func tradeOverSocket(t *testing.T, c *pokecache.Cache, host, guest *config) (hostErr, guestErr error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "trade.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	done := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		defer conn.Close()
+		done <- runTrade(host, c, newTradeConn(conn))
+	}()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	guestErr = runTrade(guest, c, newTradeConn(conn))
+	conn.Close()
+	return <-done, guestErr
+}
+
+func TestTradeOverUnixSocket(t *testing.T) {
+	quiet(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c := tradeCache()
+	ash := trainer(t, c, "ash", "pikachu\ny\n", "pikachu")
+	misty := trainer(t, c, "misty", "rattata\ny\n", "rattata")
+	pikachuUID := ash.caughtPokemon["pikachu"].UID
+
+	if hostErr, guestErr := tradeOverSocket(t, c, ash, misty); hostErr != nil || guestErr != nil {
+		t.Fatalf("trade failed: %v / %v", hostErr, guestErr)
+	}
+	if _, ok := ash.caughtPokemon["rattata"]; !ok || len(ash.caughtPokemon) != 1 {
+		t.Errorf("ash has %v, want only rattata", ash.caughtPokemon)
+	}
+	if got, ok := misty.caughtPokemon["pikachu"]; !ok || len(misty.caughtPokemon) != 1 || got.UID != pikachuUID {
+		t.Errorf("misty has %v, want only ash's pikachu", misty.caughtPokemon)
+	}
+	if ash.party[0] != "rattata" || misty.party[0] != "pikachu" {
+		t.Errorf("parties are %v and %v", ash.party, misty.party)
+	}
+	if len(ash.trades) != 1 || len(misty.trades) != 1 || ash.trades[0].ID == "" || ash.trades[0].ID != misty.trades[0].ID {
+		t.Errorf("trade histories %+v and %+v do not record the same trade", ash.trades, misty.trades)
+	}
+	if ash.pending != nil || misty.pending != nil {
+		t.Error("a finished trade is still pending")
+	}
+}
+
+func TestTradeDeclined(t *testing.T) {
+	quiet(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c := tradeCache()
+	ash := trainer(t, c, "ash", "pikachu\ny\n", "pikachu")
+	misty := trainer(t, c, "misty", "rattata\nn\n", "rattata")
+	hostErr, guestErr := tradeOverSocket(t, c, ash, misty)
+	if hostErr == nil || guestErr == nil {
+		t.Fatalf("a declined trade went through: %v / %v", hostErr, guestErr)
+	}
+	if _, ok := ash.caughtPokemon["pikachu"]; !ok || len(ash.trades) != 0 || ash.pending != nil {
+		t.Error("ash's pokedex changed after a declined trade")
+	}
+	if _, ok := misty.caughtPokemon["rattata"]; !ok || len(misty.trades) != 0 || misty.pending != nil {
+		t.Error("misty's pokedex changed after a declined trade")
+	}
+}
+
+func TestTradeChecksReceivedPokemon(t *testing.T) {
+	quiet(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c := tradeCache()
+
+	// a name that would escape the gallery directory is refused
+	ash := trainer(t, c, "ash", "pikachu\ny\n", "pikachu")
+	misty := trainer(t, c, "misty", "rattata\ny\n", "rattata")
+	misty.caughtPokemon["rattata"].Name = "../../rattata"
+	if hostErr, _ := tradeOverSocket(t, c, ash, misty); hostErr == nil {
+		t.Fatal("a pokemon with a path for a name was accepted")
+	}
+	if _, ok := ash.caughtPokemon["pikachu"]; !ok || len(ash.caughtPokemon) != 1 {
+		t.Errorf("ash has %v after refusing the trade", ash.caughtPokemon)
+	}
+
+	// forged values are clamped and missing maps filled in
+	ash = trainer(t, c, "ash", "pikachu\ny\n", "pikachu")
+	misty = trainer(t, c, "misty", "rattata\ny\n", "rattata")
+	forged := misty.caughtPokemon["rattata"]
+	forged.Level = 500
+	forged.IVs = map[string]int{"attack": 99, "speed": -5}
+	forged.EVs = nil
+	forged.KnownMoves = []string{"hyper-beam", "tackle", "tackle"}
+	forged.Stats[1].BaseStat = 255
+	if hostErr, guestErr := tradeOverSocket(t, c, ash, misty); hostErr != nil || guestErr != nil {
+		t.Fatalf("trade failed: %v / %v", hostErr, guestErr)
+	}
+	got := ash.caughtPokemon["rattata"]
+	if got.Level != maxLevel || got.IVs["attack"] != maxIV || got.IVs["speed"] != 0 {
+		t.Errorf("level %d and IVs %v were not clamped", got.Level, got.IVs)
+	}
+	if got.EVs == nil || len(got.EVs) != len(statNames) {
+		t.Errorf("EVs %v were not filled in", got.EVs)
+	}
+	if got.baseStat("attack") != 50 {
+		t.Errorf("base attack %d was taken from the offer instead of the API", got.baseStat("attack"))
+	}
+	if len(got.KnownMoves) != 1 || got.KnownMoves[0] != "tackle" {
+		t.Errorf("known moves %v were not checked against the learnset", got.KnownMoves)
+	}
+	got.gainEffort(got.Pokemon) // panicked on a nil EV map
+}
+
+func TestSettleInterruptedTrade(t *testing.T) {
+	quiet(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c := tradeCache()
+	cases := []struct {
+		name                     string
+		ashPending, mistyPending bool
+		mistyCompleted           bool
+		wantSwapped              bool
+	}{
+		// misty received ash's commit and finished, ash never heard back
+		{name: "finished on one side", ashPending: true, mistyCompleted: true, wantSwapped: true},
+		// both wrote the trade down, neither got the other's commit
+		{name: "pending on both sides", ashPending: true, mistyPending: true, wantSwapped: true},
+		// misty never wrote the trade down, so never committed it
+		{name: "unknown to the partner", ashPending: true, wantSwapped: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ash := trainer(t, c, "ash", "", "pikachu")
+			misty := trainer(t, c, "misty", "", "rattata")
+			pikachu, rattata := ash.caughtPokemon["pikachu"], misty.caughtPokemon["rattata"]
+			const id = "aaaa-bbbb"
+			if tc.ashPending {
+				ash.pending = &pendingTrade{ID: id, Partner: "misty", Gave: pikachu.UID, Received: rattata}
+			}
+			if tc.mistyPending {
+				misty.pending = &pendingTrade{ID: id, Partner: "ash", Gave: rattata.UID, Received: pikachu}
+			}
+			if tc.mistyCompleted {
+				misty.pending = &pendingTrade{ID: id, Partner: "ash", Gave: rattata.UID, Received: pikachu}
+				if err := completeTrade(misty, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if hostErr, guestErr := tradeOverSocket(t, c, ash, misty); hostErr != nil || guestErr != nil {
+				t.Fatalf("settling failed: %v / %v", hostErr, guestErr)
+			}
+			if ash.pending != nil || misty.pending != nil {
+				t.Fatal("the trade is still pending after settling")
+			}
+			_, ashHasRattata := ash.caughtPokemon["rattata"]
+			_, ashHasPikachu := ash.caughtPokemon["pikachu"]
+			if ashHasRattata != tc.wantSwapped || ashHasPikachu == tc.wantSwapped {
+				t.Errorf("ash has %v, want swapped=%v", ash.caughtPokemon, tc.wantSwapped)
+			}
+			_, mistyHasPikachu := misty.caughtPokemon["pikachu"]
+			_, mistyHasRattata := misty.caughtPokemon["rattata"]
+			if mistyHasPikachu != tc.wantSwapped || mistyHasRattata == tc.wantSwapped {
+				t.Errorf("misty has %v, want swapped=%v", misty.caughtPokemon, tc.wantSwapped)
+			}
+		})
+	}
+}
+
+func TestSettleRefusesOtherTrainers(t *testing.T) {
+	quiet(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c := tradeCache()
+	ash := trainer(t, c, "ash", "", "pikachu")
+	brock := trainer(t, c, "brock", "", "rattata")
+	ash.pending = &pendingTrade{ID: "aaaa-bbbb", Partner: "misty", Gave: ash.caughtPokemon["pikachu"].UID, Received: brock.caughtPokemon["rattata"]}
+	hostErr, guestErr := tradeOverSocket(t, c, ash, brock)
+	if hostErr == nil || guestErr == nil {
+		t.Fatalf("a trade pending with misty was settled with brock: %v / %v", hostErr, guestErr)
+	}
+	if ash.pending == nil {
+		t.Error("the pending trade was dropped")
+	}
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// monFile is an exported Pokemon. Signature is the ed25519 signature of the
+// compact Payload bytes by the exporting trainer's key, whose public half is
+// PublicKey; the payload is kept raw rather than re-encoded so the
+// indentation of the file does not matter.
+//
+// The signature shows who exported the file and that it was not changed
+// since, not that the Pokemon is genuine: anyone can sign a file with their
+// own key, so imported Pokemon are checked like traded ones. The payload
+// names the public key of the one trainer who may import it, and their
+// pokedex remembers the tokens it imported, so a copied file is worth nothing
+// anywhere else.
+type monFile struct {
+	Payload   json.RawMessage `json:"payload"`
+	PublicKey string          `json:"public_key"`
+	Signature string          `json:"signature"`
+}
+
+// monPayload is the signed part of an exported Pokemon. The token is random
+// and may only be imported once, by the trainer whose public key is
+// Recipient.
+type monPayload struct {
+	Token      string        `json:"token"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Trainer    string        `json:"trainer"`
+	Recipient  string        `json:"recipient"`
+	Pokemon    *OwnedPokemon `json:"pokemon"`
+}
+
+// newUID returns a random identifier for an owned Pokemon or a token.
+func newUID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// configDir is where the pokedex keeps aliases, profiles and trade data.
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go_pokedex"), nil
+}
+
+// trainerKey returns the signing key of the trainer, kept in
+// keys/<trainer>.key in the config directory and created on first use.
+func trainerKey(configure *config) (ed25519.PrivateKey, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "keys", trainerName(configure)+".key")
+	if data, err := os.ReadFile(path); err == nil {
+		seed, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("%s is damaged", path)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key.Seed())+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// publicKeyString is how trainers hand their public key to whoever exports a
+// Pokemon for them.
+func publicKeyString(key ed25519.PrivateKey) string {
+	return hex.EncodeToString(key.Public().(ed25519.PublicKey))
+}
+
+// parsePublicKey reads a key printed by publicKeyString.
+func parsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(s)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%q is not a trainer key, the recipient can show theirs with import-mon --key", s)
+	}
+	return key, nil
+}
+
+// fingerprint is a short form of a public key to show to trainers.
+func fingerprint(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// sealMon signs payload with key and returns the contents of the file.
+func sealMon(key ed25519.PrivateKey, payload monPayload) ([]byte, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(monFile{
+		Payload:   raw,
+		PublicKey: publicKeyString(key),
+		Signature: hex.EncodeToString(ed25519.Sign(key, raw)),
+	}, "", "  ")
+}
+
+// openMon checks the signature of an exported Pokemon and returns its
+// payload and the key that signed it.
+func openMon(data []byte) (monPayload, ed25519.PublicKey, error) {
+	payload := monPayload{}
+	file := monFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return payload, nil, fmt.Errorf("not an exported pokemon: %v", err)
+	}
+	key, err := hex.DecodeString(file.PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return payload, nil, fmt.Errorf("not an exported pokemon: bad public key")
+	}
+	signature, err := hex.DecodeString(file.Signature)
+	if err != nil {
+		return payload, nil, fmt.Errorf("not an exported pokemon: bad signature")
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, file.Payload); err != nil {
+		return payload, nil, fmt.Errorf("not an exported pokemon: %v", err)
+	}
+	if !ed25519.Verify(key, compact.Bytes(), signature) {
+		return payload, nil, fmt.Errorf("the file has been tampered with")
+	}
+	if err := json.Unmarshal(file.Payload, &payload); err != nil {
+		return payload, nil, fmt.Errorf("not an exported pokemon: %v", err)
+	}
+	if payload.Token == "" || payload.Trainer == "" || strings.ContainsAny(payload.Trainer, "\r\n") || payload.Recipient == "" || payload.Pokemon == nil || payload.Pokemon.Name == "" {
+		return payload, nil, fmt.Errorf("not an exported pokemon")
+	}
+	return payload, key, nil
+}
+
+// knownTrainersFile maps trainer names to the fingerprint of the key the
+// first file imported from them was signed with, one "fingerprint name" per
+// line.
+func knownTrainersFile() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "known-trainers"), nil
+}
+
+// knownTrainer returns the fingerprint recorded for trainer, or "" if no
+// file from them has been imported yet.
+func knownTrainer(trainer string) (string, error) {
+	path, err := knownTrainersFile()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if print, name, ok := strings.Cut(line, " "); ok && name == trainer {
+			return print, nil
+		}
+	}
+	return "", nil
+}
+
+func rememberTrainer(trainer, print string) error {
+	path, err := knownTrainersFile()
+	if err != nil {
+		return err
+	}
+	return appendLine(path, print+" "+trainer)
+}
+
+// usedTokensFile lists the tokens already imported on this machine, shared
+// by every profile so a file cannot be imported into two of them.
+func usedTokensFile() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "used-tokens"), nil
+}
+
+func tokenUsed(token string) (bool, error) {
+	path, err := usedTokensFile()
+	if err != nil {
+		return false, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == token {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+func useToken(token string) error {
+	path, err := usedTokensFile()
+	if err != nil {
+		return err
+	}
+	return appendLine(path, token)
+}
+
+// appendLine adds a line to a file and syncs it to disk.
+func appendLine(path, line string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// findOwned looks a caught Pokemon up by its UID or its name.
+func findOwned(configure *config, id string) (*OwnedPokemon, bool) {
+	if owned, exists := configure.caughtPokemon[strings.ToLower(id)]; exists {
+		return owned, true
+	}
+	for _, owned := range configure.caughtPokemon {
+		if owned.UID == strings.ToLower(id) {
+			return owned, true
+		}
+	}
+	return nil, false
+}
+
+// removeOwned takes a Pokemon out of the pokedex and the party.
+func removeOwned(configure *config, name string) {
+	delete(configure.caughtPokemon, name)
+	for i, member := range configure.party {
+		if member == name {
+			configure.party = append(configure.party[:i], configure.party[i+1:]...)
+			break
+		}
+	}
+}
+
+func commandExportMon(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) < 2 || len(args) > 3 {
+		return usageError(configure, "export-mon")
+	}
+	owned, exists := findOwned(configure, args[0])
+	if !exists {
+		return fmt.Errorf("you have not caught %s%s", args[0], notCaughtHint(configure, c, strings.ToLower(args[0])))
+	}
+	recipient, err := parsePublicKey(strings.ToLower(args[1]))
+	if err != nil {
+		return err
+	}
+	path := owned.Name + "-" + owned.UID + ".pokemon.json"
+	if len(args) == 3 {
+		path = args[2]
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	key, err := trainerKey(configure)
+	if err != nil {
+		return fmt.Errorf("no signing key: %v", err)
+	}
+	data, err := sealMon(key, monPayload{
+		Token:      newUID() + newUID(),
+		ExportedAt: time.Now().UTC(),
+		Trainer:    trainerName(configure),
+		Recipient:  hex.EncodeToString(recipient),
+		Pokemon:    owned,
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+	party := slices.Clone(configure.party)
+	removeOwned(configure, owned.Name)
+	if err := saveProfile(configure); err != nil {
+		// keep the Pokemon rather than have it in two places
+		os.Remove(path)
+		configure.caughtPokemon[owned.Name] = owned
+		configure.party = party
+		return err
+	}
+	fmt.Printf(configure.tr("%s left your box and was written to %s")+"\n", configure.pokemonName(c, owned.Name), path)
+	return nil
+}
+
+func commandImportMon(configure *config, c *pokecache.Cache, AreaName string) error {
+	flags, args := parseArgs(AreaName)
+	_, showKey := flags["key"]
+	if !showKey && len(args) != 1 {
+		return usageError(configure, "import-mon")
+	}
+	own, err := trainerKey(configure)
+	if err != nil {
+		return fmt.Errorf("no trainer key: %v", err)
+	}
+	if showKey {
+		fmt.Printf(configure.tr("your trainer key, give it to whoever exports a pokemon for you: %s")+"\n", publicKeyString(own))
+		return nil
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	payload, key, err := openMon(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v", args[0], err)
+	}
+	if payload.Recipient != publicKeyString(own) {
+		return fmt.Errorf("%s was exported for another trainer", args[0])
+	}
+	mon := payload.Pokemon
+	signer := fingerprint(key)
+	known, err := knownTrainer(payload.Trainer)
+	if err != nil {
+		return err
+	}
+	if known != "" && known != signer {
+		fmt.Printf(configure.tr("%s is signed by key %s, but earlier files from %s were signed by %s")+"\n", args[0], signer, payload.Trainer, known)
+		if answer := prompt(configure, configure.tr("import it anyway? (y/n) > ")); answer != "y" && answer != "yes" {
+			return fmt.Errorf("%s was not imported", args[0])
+		}
+	}
+	if err := checkReceived(c, mon); err != nil {
+		return fmt.Errorf("%s: %v", args[0], err)
+	}
+	used, err := tokenUsed(payload.Token)
+	if err != nil {
+		return err
+	}
+	if used {
+		return fmt.Errorf("%s has already been imported", args[0])
+	}
+	if _, exists := configure.caughtPokemon[mon.Name]; exists {
+		return fmt.Errorf("you already have a %s", mon.Name)
+	}
+	// spend the token first: a crash may then lose the pokemon, but can never
+	// let the same file be imported twice
+	if err := useToken(payload.Token); err != nil {
+		return err
+	}
+	if mon.UID == "" {
+		mon.UID = newUID()
+	}
+	if known == "" {
+		if err := rememberTrainer(payload.Trainer, signer); err != nil {
+			return err
+		}
+	}
+	configure.caughtPokemon[mon.Name] = mon
+	if len(configure.party) < 6 {
+		configure.party = append(configure.party, mon.Name)
+	}
+	if err := saveProfile(configure); err != nil {
+		return err
+	}
+	fmt.Printf(configure.tr("%s from %s (key %s) joined your box")+"\n", configure.pokemonName(c, mon.Name), payload.Trainer, signer)
+	return nil
+}
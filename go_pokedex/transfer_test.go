@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestSealOpenMon(t *testing.T) {
+	key := testKey(t)
+	mon := &OwnedPokemon{Pokemon: testPokemon(t, "pikachu", []string{"electric"}, [6]int{35, 55, 40, 50, 50, 90}), UID: "3f2a9c1d0b7e4a56", Level: 12}
+	data, err := sealMon(key, monPayload{Token: "token", ExportedAt: time.Now().UTC(), Trainer: "ash", Recipient: publicKeyString(testKey(t)), Pokemon: mon})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload, signer, err := openMon(data)
+	if err != nil {
+		t.Fatalf("openMon: %v", err)
+	}
+	if !signer.Equal(key.Public()) || payload.Trainer != "ash" || payload.Pokemon.UID != mon.UID || payload.Pokemon.Level != 12 {
+		t.Errorf("openMon = %+v signed by %x, want ash's pikachu", payload, signer)
+	}
+
+	// the payload may be reformatted, as long as its content is the same
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "\t"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := openMon(indented.Bytes()); err != nil {
+		t.Errorf("re-indented file: %v", err)
+	}
+
+	tampered := bytes.Replace(data, []byte(`"level": 12`), []byte(`"level": 99`), 1)
+	if bytes.Equal(tampered, data) {
+		t.Fatal("level not found in payload")
+	}
+	if _, _, err := openMon(tampered); err == nil {
+		t.Error("tampered payload was accepted")
+	}
+
+	// signing with one key and presenting another must fail
+	file := monFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	file.PublicKey = hex.EncodeToString(testKey(t).Public().(ed25519.PublicKey))
+	swapped, err := json.Marshal(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := openMon(swapped); err == nil {
+		t.Error("file with a swapped public key was accepted")
+	}
+}
+
+func TestExportImportMon(t *testing.T) {
+	quiet(t)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	c := tradeCache()
+	ash := trainer(t, c, "ash", "", "pikachu", "rattata")
+	misty := trainer(t, c, "misty", "n\n")
+	mistyKey, err := trainerKey(misty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := captureStdout(t, func() { err = commandImportMon(misty, c, "--key") })
+	if err != nil || !strings.Contains(out, publicKeyString(mistyKey)) {
+		t.Errorf("import-mon --key printed %q, %v, want misty's key", out, err)
+	}
+	path := filepath.Join(t.TempDir(), "pikachu.pokemon.json")
+
+	if err := commandExportMon(ash, c, "pikachu not-a-key "+path); err == nil || !strings.Contains(err.Error(), "not a trainer key") {
+		t.Errorf("export to a bad key: %v", err)
+	}
+	if err := commandExportMon(ash, c, "pikachu "+publicKeyString(mistyKey)+" "+path); err != nil {
+		t.Fatalf("export-mon: %v", err)
+	}
+
+	// the file is only good for misty, even on a machine that never saw it
+	brock := trainer(t, c, "brock", "")
+	if err := commandImportMon(brock, c, path); err == nil || !strings.Contains(err.Error(), "another trainer") {
+		t.Errorf("brock imported misty's file: %v", err)
+	}
+	if _, ok := brock.caughtPokemon["pikachu"]; ok {
+		t.Error("brock has misty's pikachu")
+	}
+	if _, ok := ash.caughtPokemon["pikachu"]; ok || len(ash.party) != 1 {
+		t.Errorf("ash still has pikachu after exporting it: %v %v", ash.caughtPokemon, ash.party)
+	}
+	if err := commandImportMon(misty, c, path); err != nil {
+		t.Fatalf("import-mon: %v", err)
+	}
+	if _, ok := misty.caughtPokemon["pikachu"]; !ok {
+		t.Errorf("misty has %v, want pikachu", misty.caughtPokemon)
+	}
+
+	delete(misty.caughtPokemon, "pikachu")
+	if err := commandImportMon(misty, c, path); err == nil || !strings.Contains(err.Error(), "already been imported") {
+		t.Errorf("second import: %v, want already imported", err)
+	}
+
+	// a file claiming to be from ash but signed by another key needs consent
+	forged, err := sealMon(testKey(t), monPayload{Token: "forged", ExportedAt: time.Now().UTC(), Trainer: "ash", Recipient: publicKeyString(mistyKey), Pokemon: trainer(t, c, "ash", "", "rattata").caughtPokemon["rattata"]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	forgedPath := filepath.Join(t.TempDir(), "rattata.pokemon.json")
+	if err := os.WriteFile(forgedPath, forged, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := commandImportMon(misty, c, forgedPath); err == nil || !strings.Contains(err.Error(), "was not imported") {
+		t.Errorf("file signed with a different key for ash: %v, want it refused when misty says no", err)
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+var romanNumerals = map[string]int{
+	"i": 1, "ii": 2, "iii": 3, "iv": 4, "v": 5, "vi": 6, "vii": 7, "viii": 8, "ix": 9, "x": 10,
+}
+
+// generationNumber turns "generation-iv" (or just "4") into 4. It returns 0
+// for anything it does not understand.
+func generationNumber(name string) int {
+	if n, err := strconv.Atoi(name); err == nil {
+		return n
+	}
+	return romanNumerals[strings.TrimPrefix(name, "generation-")]
+}
+
+// relationsIn returns the damage relations that applied in generation gen.
+// Each past entry holds the relations used up to and including its
+// generation, so the earliest one not older than gen wins.
+func (t typeData) relationsIn(gen int) typeRelations {
+	if gen == 0 {
+		return t.DamageRelations
+	}
+	best, bestGen := t.DamageRelations, 0
+	for _, past := range t.PastDamageRelations {
+		n := generationNumber(past.Generation.Name)
+		if n >= gen && (bestGen == 0 || n < bestGen) {
+			best, bestGen = past.DamageRelations, n
+		}
+	}
+	return best
+}
+
+// typesIn returns the Pokemon's types in generation gen, honoring PastTypes
+// the same way relationsIn honors past damage relations.
+func (p Pokemon) typesIn(gen int) []string {
+	if gen == 0 {
+		return p.typeNames()
+	}
+	bestGen := 0
+	names := p.typeNames()
+	for _, past := range p.PastTypes {
+		n := generationNumber(past.Generation.Name)
+		if n >= gen && (bestGen == 0 || n < bestGen) {
+			bestGen = n
+			names = names[:0:0]
+			for _, t := range past.Types {
+				names = append(names, t.Type.Name)
+			}
+		}
+	}
+	return names
+}
+
+type namedList struct {
+	Count   int `json:"count"`
+	Results []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"results"`
+}
+
+// allTypes lists the battle types that exist in generation gen.
+func allTypes(c *pokecache.Cache, gen int) ([]string, error) {
+	list := namedList{}
+	if err := fetchJSON(c, pokeAPI+"type?limit=100", &list); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Results))
+	for _, r := range list.Results {
+		if r.Name == "unknown" || r.Name == "shadow" || r.Name == "stellar" {
+			continue
+		}
+		if gen != 0 {
+			t, err := fetchType(c, r.Name)
+			if err != nil {
+				return nil, err
+			}
+			if generationNumber(t.Generation.Name) > gen {
+				continue
+			}
+		}
+		names = append(names, r.Name)
+	}
+	return names, nil
+}
+
+func isType(c *pokecache.Cache, name string) (bool, error) {
+	types, err := allTypes(c, 0)
+	if err != nil {
+		return false, err
+	}
+	for _, t := range types {
+		if t == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// resolveTypes accepts either a type name or a Pokemon name and returns the
+// types it stands for in generation gen.
+func resolveTypes(configure *config, c *pokecache.Cache, name string, gen int) ([]string, error) {
+	if ok, err := isType(c, name); err != nil {
+		return nil, err
+	} else if ok {
+		return []string{name}, nil
+	}
+	if owned, exists := configure.caughtPokemon[name]; exists {
+		return owned.typesIn(gen), nil
+	}
+	poke, err := fetchPokemon(c, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither a type nor a pokemon", name)
+	}
+	return poke.typesIn(gen), nil
+}
+
+// localizeTypes returns the display names of a list of types.
+func localizeTypes(configure *config, c *pokecache.Cache, types []string) []string {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, configure.localize(c, "type", t))
+	}
+	return names
+}
+
+// subjectName is the display name of a matchup argument, a type or a Pokemon.
+func subjectName(configure *config, c *pokecache.Cache, name string) string {
+	if ok, _ := isType(c, name); ok {
+		return configure.localize(c, "type", name)
+	}
+	return configure.pokemonName(c, name)
+}
+
+func describeMultiplier(m float64) string {
+	switch {
+	case m == 0:
+		return "no effect"
+	case m > 1:
+		return "super effective"
+	case m < 1:
+		return "not very effective"
+	}
+	return "normal damage"
+}
+
+func formatMultiplier(m float64) string {
+	return strconv.FormatFloat(m, 'f', -1, 64) + "x"
+}
+
+func genFlag(flags map[string]string) (int, error) {
+	value, ok := flags["gen"]
+	if !ok {
+		return 0, nil
+	}
+	gen := generationNumber(value)
+	if gen == 0 {
+		return 0, fmt.Errorf("unknown generation %q", value)
+	}
+	return gen, nil
+}
+
+func commandMatchup(configure *config, c *pokecache.Cache, AreaName string) error {
+	flags, args := parseArgs(AreaName)
+	if len(args) != 2 {
+		return usageError(configure, "matchup")
+	}
+	gen, err := genFlag(flags)
+	if err != nil {
+		return err
+	}
+	attackers, err := resolveTypes(configure, c, args[0], gen)
+	if err != nil {
+		return err
+	}
+	defenders, err := resolveTypes(configure, c, args[1], gen)
+	if err != nil {
+		return err
+	}
+	for _, attack := range attackers {
+		m, err := typeEffectiveness(c, attack, defenders, gen)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s -> %s: %s (%s)\n", configure.localize(c, "type", attack), strings.Join(localizeTypes(configure, c, defenders), "/"),
+			formatMultiplier(m), configure.tr(describeMultiplier(m)))
+	}
+	return nil
+}
+
+func commandWeaknesses(configure *config, c *pokecache.Cache, AreaName string) error {
+	flags, args := parseArgs(AreaName)
+	if len(args) != 1 {
+		return usageError(configure, "weaknesses")
+	}
+	gen, err := genFlag(flags)
+	if err != nil {
+		return err
+	}
+	defenders, err := resolveTypes(configure, c, args[0], gen)
+	if err != nil {
+		return err
+	}
+	attacks, err := allTypes(c, gen)
+	if err != nil {
+		return err
+	}
+	groups := make(map[float64][]string)
+	for _, attack := range attacks {
+		m, err := typeEffectiveness(c, attack, defenders, gen)
+		if err != nil {
+			return err
+		}
+		groups[m] = append(groups[m], attack)
+	}
+	multipliers := make([]float64, 0, len(groups))
+	for m := range groups {
+		multipliers = append(multipliers, m)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(multipliers)))
+	fmt.Printf("%s (%s):\n", subjectName(configure, c, args[0]), strings.Join(localizeTypes(configure, c, defenders), "/"))
+	for _, m := range multipliers {
+		fmt.Printf("  %-5s %s\n", formatMultiplier(m), strings.Join(localizeTypes(configure, c, groups[m]), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+func TestRelationsIn(t *testing.T) {
+	// Ghost and dark attacks were halved by steel up to generation 5, and
+	// an invented entry for generation 1 checks that the earliest
+	// applicable entry wins.
+	raw := `{
+		"name": "ghost",
+		"damage_relations": {"double_damage_to": [{"name": "ghost"}, {"name": "psychic"}], "half_damage_to": [{"name": "dark"}], "no_damage_to": [{"name": "normal"}]},
+		"past_damage_relations": [
+			{"generation": {"name": "generation-v"}, "damage_relations": {"double_damage_to": [{"name": "ghost"}, {"name": "psychic"}], "half_damage_to": [{"name": "dark"}, {"name": "steel"}], "no_damage_to": [{"name": "normal"}]}},
+			{"generation": {"name": "generation-i"}, "damage_relations": {"double_damage_to": [{"name": "ghost"}], "no_damage_to": [{"name": "normal"}, {"name": "psychic"}]}}
+		]
+	}`
+	ghost := typeData{}
+	if err := json.Unmarshal([]byte(raw), &ghost); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		gen      int
+		defender string
+		want     float64
+	}{
+		{0, "steel", 1},
+		{0, "psychic", 2},
+		{6, "steel", 1},
+		{5, "steel", 0.5},
+		{2, "steel", 0.5},
+		{2, "psychic", 2},
+		{1, "psychic", 0},
+		{1, "steel", 1},
+		{9, "normal", 0},
+	}
+	for _, tc := range cases {
+		if got := ghost.relationsIn(tc.gen).multiplierAgainst(tc.defender); got != tc.want {
+			t.Errorf("ghost vs %s in gen %d = %v, want %v", tc.defender, tc.gen, got, tc.want)
+		}
+	}
+}
+
+func TestGenerationNumber(t *testing.T) {
+	cases := map[string]int{"generation-iv": 4, "generation-ix": 9, "3": 3, "x": 10, "generation-foo": 0, "": 0}
+	for name, want := range cases {
+		if got := generationNumber(name); got != want {
+			t.Errorf("generationNumber(%q) = %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestTypesIn(t *testing.T) {
+	// clefairy was normal type until fairy arrived in generation 6
+	clefairy := Pokemon{}
+	raw := `{"name":"clefairy","types":[{"slot":1,"type":{"name":"fairy"}}],"past_types":[{"generation":{"name":"generation-v"},"types":[{"slot":1,"type":{"name":"normal"}}]}]}`
+	if err := json.Unmarshal([]byte(raw), &clefairy); err != nil {
+		t.Fatal(err)
+	}
+	cases := map[int][]string{0: {"fairy"}, 1: {"normal"}, 5: {"normal"}, 6: {"fairy"}, 9: {"fairy"}}
+	for gen, want := range cases {
+		if got := clefairy.typesIn(gen); !reflect.DeepEqual(got, want) {
+			t.Errorf("clefairy types in gen %d = %v, want %v", gen, got, want)
+		}
+	}
+}
+
+func TestTypeEffectiveness(t *testing.T) {
+	c := pokecache.NewCache(time.Hour)
+	c.Add(pokeAPI+"type/ice", []byte(`{"name":"ice","damage_relations":{"double_damage_to":[{"name":"dragon"},{"name":"ground"},{"name":"flying"}],"half_damage_to":[{"name":"water"},{"name":"ice"}]}}`))
+	c.Add(pokeAPI+"type/ground", []byte(`{"name":"ground","damage_relations":{"double_damage_to":[{"name":"electric"}],"no_damage_to":[{"name":"flying"}]}}`))
+	cases := []struct {
+		attack    string
+		defenders []string
+		want      float64
+	}{
+		{"ice", []string{"dragon", "ground"}, 4},
+		{"ice", []string{"water", "ice"}, 0.25},
+		{"ice", []string{"water", "flying"}, 1},
+		{"ground", []string{"electric", "flying"}, 0},
+		{"ground", []string{"electric"}, 2},
+	}
+	for _, tc := range cases {
+		got, err := typeEffectiveness(c, tc.attack, tc.defenders, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("%s vs %v = %v, want %v", tc.attack, tc.defenders, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+type versionData struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	VersionGroup struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"version_group"`
+}
+
+// versionFilter limits game data to one version (e.g. "red") and the version
+// group it belongs to (e.g. "red-blue"). The zero value lets everything
+// through.
+type versionFilter struct {
+	Version string `json:"version"`
+	Group   string `json:"group"`
+}
+
+func (f versionFilter) matchVersion(name string) bool {
+	return f.Version == "" || f.Version == name
+}
+
+func (f versionFilter) matchGroup(name string) bool {
+	return f.Group == "" || f.Group == name
+}
+
+// versionGroupSprites maps version groups to the inspect --sprite selector
+// showing the sprite from those games.
+var versionGroupSprites = map[string]string{
+	"red-blue":                  "gen1-red-blue",
+	"yellow":                    "gen1-yellow",
+	"gold-silver":               "gen2-gold",
+	"crystal":                   "gen2-crystal",
+	"ruby-sapphire":             "gen3-ruby-sapphire",
+	"emerald":                   "gen3-emerald",
+	"firered-leafgreen":         "gen3-firered-leafgreen",
+	"diamond-pearl":             "gen4-diamond-pearl",
+	"platinum":                  "gen4-platinum",
+	"heartgold-soulsilver":      "gen4-heartgold-soulsilver",
+	"black-white":               "gen5-black-white",
+	"x-y":                       "gen6-x-y",
+	"omega-ruby-alpha-sapphire": "gen6-omegaruby-alphasapphire",
+	"ultra-sun-ultra-moon":      "gen7-ultra-sun-ultra-moon",
+}
+
+func resolveVersion(configure *config, c *pokecache.Cache, name string) (versionFilter, error) {
+	v := versionData{}
+	err := fetchJSON(c, pokeAPI+"version/"+name, &v)
+	if err == errNotFound {
+		return versionFilter{}, notFoundError(configure, c, "version", name)
+	}
+	if err != nil {
+		return versionFilter{}, err
+	}
+	return versionFilter{Version: v.Name, Group: v.VersionGroup.Name}, nil
+}
+
+// gameFilter returns the filter for a command: the --version flag when given,
+// otherwise the trainer's current game version.
+func gameFilter(configure *config, c *pokecache.Cache, flags map[string]string) (versionFilter, error) {
+	if name, ok := flags["version"]; ok {
+		if name == "" || name == "all" {
+			return versionFilter{}, nil
+		}
+		return resolveVersion(configure, c, name)
+	}
+	return configure.game, nil
+}
+
+func commandVersion(configure *config, c *pokecache.Cache, AreaName string) error {
+	_, args := parseArgs(AreaName)
+	if len(args) == 0 {
+		if configure.game.Version == "" {
+			fmt.Println(configure.tr("no game version set, showing data from every game"))
+		} else {
+			fmt.Printf(configure.tr("current game version: %s (%s)")+"\n", configure.localize(c, "version", configure.game.Version), configure.game.Group)
+		}
+		return nil
+	}
+	if args[0] == "all" || args[0] == "none" {
+		configure.game = versionFilter{}
+		fmt.Println(configure.tr("showing data from every game"))
+		return nil
+	}
+	filter, err := resolveVersion(configure, c, args[0])
+	if err != nil {
+		return err
+	}
+	configure.game = filter
+	fmt.Printf(configure.tr("current game version set to %s (%s)")+"\n", configure.localize(c, "version", filter.Version), filter.Group)
+	return nil
+}
@@ -0,0 +1,179 @@
+// A small client for the go_pokedex JSON API, see /api/openapi.json.
+"use strict";
+
+const view = document.getElementById("view");
+
+// maxStat is the width of a full stat bar.
+const maxStat = 255;
+
+async function api(path, options) {
+  const res = await fetch("/api/" + path, options);
+  const body = await res.json();
+  if (!res.ok) {
+    throw new Error(body.error || res.statusText);
+  }
+  return body;
+}
+
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (key.startsWith("on")) {
+      node.addEventListener(key.slice(2), value);
+    } else {
+      node.setAttribute(key, value);
+    }
+  }
+  node.append(...children);
+  return node;
+}
+
+function sprite(name, large) {
+  return el("img", {
+    class: large ? "sprite large" : "sprite",
+    src: "/api/pokedex/" + encodeURIComponent(name) + "/sprite",
+    alt: name,
+  });
+}
+
+function genderSymbol(gender) {
+  return { male: "♂", female: "♀" }[gender] || "";
+}
+
+async function showPokedex() {
+  const dex = await api("pokedex");
+  if (dex.count === 0) {
+    view.replaceChildren(el("p", {}, "You have not caught any pokemon yet, explore an area to find some."));
+    return;
+  }
+  const cards = dex.pokemon.map((p) =>
+    el("a", { class: "card", href: "#pokemon/" + encodeURIComponent(p.name) },
+      sprite(p.name),
+      el("div", {}, "#" + p.id + " " + p.display_name + " " + genderSymbol(p.gender)),
+      el("div", {}, "Lv" + p.level, p.shiny ? el("span", { class: "shiny" }, " ★") : ""),
+    ));
+  view.replaceChildren(el("h2", {}, "Your Pokedex (" + dex.count + ")"), el("div", { class: "grid" }, ...cards));
+}
+
+async function showPokemon(name) {
+  const p = await api("pokedex/" + encodeURIComponent(name));
+  const facts = [
+    ["Number", p.id],
+    ["Gender", p.gender + " " + genderSymbol(p.gender)],
+    ["Level", p.level],
+    ["Exp", p.next_level_exp ? p.exp + " / " + p.next_level_exp : p.exp],
+    ["Nature", p.nature],
+    ["Friendship", p.friendship],
+    ["Height", p.height],
+    ["Weight", p.weight],
+    ["Types", p.types.join(", ")],
+    ["Moves", p.moves.join(", ")],
+  ];
+  if (p.shiny) {
+    facts.push(["Shiny", "yes ★"]);
+  }
+  const stats = p.stats.map((s) =>
+    el("div", { class: "stat", title: "base " + s.base + ", IV " + s.iv + ", EV " + s.ev },
+      el("span", {}, s.name),
+      el("span", {}, s.value),
+      el("div", { class: "bar" }, el("div", { style: "width:" + Math.min(100, (100 * s.value) / maxStat) + "%" })),
+    ));
+  view.replaceChildren(
+    el("h2", {}, p.display_name),
+    el("div", { class: "details" },
+      sprite(p.name, true),
+      el("dl", {}, ...facts.flatMap(([k, v]) => [el("dt", {}, k), el("dd", {}, String(v))])),
+      el("div", { style: "flex:1;min-width:260px" }, el("h3", {}, "Stats"), ...stats),
+    ),
+  );
+}
+
+async function showAreas(page) {
+  const list = await api("areas?page=" + page);
+  const items = list.areas.map((name) => el("li", {}, el("a", { href: "#area/" + encodeURIComponent(name) }, name)));
+  const pager = el("div", { class: "pager" });
+  if (page > 1) {
+    pager.append(el("a", { href: "#areas/" + (page - 1) }, "← previous"));
+  }
+  pager.append("page " + list.page + " of " + list.pages);
+  if (page < list.pages) {
+    pager.append(el("a", { href: "#areas/" + (page + 1) }, "next →"));
+  }
+  view.replaceChildren(el("h2", {}, "Location areas"), pager, el("ul", { class: "areas" }, ...items));
+}
+
+async function catchPokemon(name, status) {
+  status.textContent = "…";
+  try {
+    const result = await api("pokemon/" + encodeURIComponent(name) + "/catch", { method: "POST" });
+    status.textContent = result.messages.join(". ");
+  } catch (err) {
+    status.textContent = err.message;
+  }
+}
+
+async function travel(name, status) {
+  status.textContent = "…";
+  try {
+    const result = await api("areas/" + encodeURIComponent(name) + "/travel", { method: "POST" });
+    status.textContent = "You are now in " + result.display_name + ".";
+  } catch (err) {
+    status.textContent = err.message;
+  }
+}
+
+async function showArea(name) {
+  const area = await api("areas/" + encodeURIComponent(name));
+  const travelStatus = el("span");
+  const sections = [
+    el("h2", {}, area.display_name),
+    el("p", {}, el("button", { onclick: () => travel(area.name, travelStatus) }, "travel here"), " ", travelStatus),
+  ];
+  if (area.methods.length === 0) {
+    sections.push(el("p", {}, "No pokemon found."));
+  }
+  for (const m of area.methods) {
+    const rates = Object.entries(m.rates || {}).map(([v, r]) => v + " " + r + "%").join(", ");
+    sections.push(el("h3", {}, m.method + (rates ? " (encounter rate: " + rates + ")" : "")));
+    const rows = m.encounters.map((e) => {
+      const status = el("span");
+      const levels = e.min_level === e.max_level ? e.min_level : e.min_level + "-" + e.max_level;
+      const chances = Object.entries(e.chances).map(([v, c]) => v + " " + c + "%").join(", ");
+      return el("tr", {},
+        el("td", {}, e.pokemon),
+        el("td", {}, String(levels)),
+        el("td", {}, chances),
+        el("td", {}, e.conditions.join(", ") || "-"),
+        el("td", {}, el("button", { onclick: () => catchPokemon(e.pokemon, status) }, "catch"), " ", status),
+      );
+    });
+    sections.push(el("table", {},
+      el("tr", {}, ...["pokemon", "level", "chance", "conditions", ""].map((h) => el("th", {}, h))),
+      ...rows));
+  }
+  view.replaceChildren(...sections);
+}
+
+async function route() {
+  const [page, arg] = decodeURIComponent(location.hash.slice(1)).split("/");
+  try {
+    switch (page) {
+      case "pokemon":
+        await showPokemon(arg);
+        break;
+      case "areas":
+        await showAreas(Number(arg) || 1);
+        break;
+      case "area":
+        await showArea(arg);
+        break;
+      default:
+        await showPokedex();
+    }
+  } catch (err) {
+    view.replaceChildren(el("p", { class: "error" }, err.message));
+  }
+}
+
+window.addEventListener("hashchange", route);
+route();
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Raikoa414/go_pokedex/internal"
+)
+
+// areaEncounter is one entry of a Pokemon's location_area_encounters.
+type areaEncounter struct {
+	LocationArea struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location_area"`
+	VersionDetails []struct {
+		MaxChance int `json:"max_chance"`
+		Version   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version"`
+		EncounterDetails []struct {
+			MinLevel        int `json:"min_level"`
+			MaxLevel        int `json:"max_level"`
+			ConditionValues []struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"condition_values"`
+			Chance int `json:"chance"`
+			Method struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"method"`
+		} `json:"encounter_details"`
+	} `json:"version_details"`
+}
+
+// encounterSummary merges the encounter details of one area, version and
+// method into a level range and a chance.
+type encounterSummary struct {
+	area     string
+	minLevel int
+	maxLevel int
+	// chance per set of conditions such as time-night or swarm-yes; the
+	// slots sharing conditions add up, different conditions are alternatives
+	conditions map[string]int
+}
+
+// chance is the best chance of meeting the Pokemon under any one set of
+// conditions.
+func (s *encounterSummary) chance() int {
+	best := 0
+	for _, chance := range s.conditions {
+		best = max(best, chance)
+	}
+	return min(best, 100)
+}
+
+// summarizeEncounters sorts the encounters matching filter by version, method
+// and area.
+func summarizeEncounters(encounters []areaEncounter, filter versionFilter) map[string]map[string]map[string]*encounterSummary {
+	// version -> method -> area
+	grouped := make(map[string]map[string]map[string]*encounterSummary)
+	for _, e := range encounters {
+		for _, v := range e.VersionDetails {
+			if !filter.matchVersion(v.Version.Name) {
+				continue
+			}
+			for _, d := range v.EncounterDetails {
+				methods, ok := grouped[v.Version.Name]
+				if !ok {
+					methods = make(map[string]map[string]*encounterSummary)
+					grouped[v.Version.Name] = methods
+				}
+				areas, ok := methods[d.Method.Name]
+				if !ok {
+					areas = make(map[string]*encounterSummary)
+					methods[d.Method.Name] = areas
+				}
+				s, ok := areas[e.LocationArea.Name]
+				if !ok {
+					s = &encounterSummary{area: e.LocationArea.Name, minLevel: d.MinLevel, maxLevel: d.MaxLevel, conditions: make(map[string]int)}
+					areas[e.LocationArea.Name] = s
+				}
+				s.minLevel = min(s.minLevel, d.MinLevel)
+				s.maxLevel = max(s.maxLevel, d.MaxLevel)
+				conditions := make([]string, 0, len(d.ConditionValues))
+				for _, cv := range d.ConditionValues {
+					conditions = append(conditions, cv.Name)
+				}
+				sort.Strings(conditions)
+				s.conditions[strings.Join(conditions, ",")] += d.Chance
+			}
+		}
+	}
+	return grouped
+}
+
+func commandWhere(configure *config, c *pokecache.Cache, AreaName string) error {
+	flags, args := parseArgs(AreaName)
+	if len(args) != 1 {
+		return usageError(configure, "where")
+	}
+	filter, err := gameFilter(configure, c, flags)
+	if err != nil {
+		return err
+	}
+	name := args[0]
+	poke := Pokemon{}
+	if owned, exists := configure.caughtPokemon[name]; exists {
+		poke = owned.Pokemon
+	} else {
+		var err error
+		poke, err = fetchPokemon(c, name)
+		if err == errNotFound {
+			return notFoundError(configure, c, "pokemon", name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	encounters := make([]areaEncounter, 0)
+	if err := fetchJSON(c, poke.LocationAreaEncounters, &encounters); err != nil {
+		return fmt.Errorf("unable to get encounters for %s: %v", name, err)
+	}
+
+	grouped := summarizeEncounters(encounters, filter)
+
+	if len(grouped) == 0 {
+		if filter.Version != "" {
+			fmt.Printf(configure.tr("%s can't be found in the wild in %s")+"\n", configure.pokemonName(c, poke.Name), configure.localize(c, "version", filter.Version))
+		} else {
+			fmt.Printf(configure.tr("%s can't be found in the wild")+"\n", configure.pokemonName(c, poke.Name))
+		}
+		return nil
+	}
+
+	areaNumbers := make(map[string]int)
+	areaList := make([]string, 0)
+	for _, version := range sortedKeys(grouped) {
+		fmt.Printf("%s:\n", configure.localize(c, "version", version))
+		for _, method := range sortedKeys(grouped[version]) {
+			fmt.Printf("  %s:\n", configure.localize(c, "encounter-method", method))
+			for _, area := range sortedKeys(grouped[version][method]) {
+				s := grouped[version][method][area]
+				if _, ok := areaNumbers[area]; !ok {
+					areaList = append(areaList, area)
+					areaNumbers[area] = len(areaList)
+				}
+				levels := strconv.Itoa(s.minLevel)
+				if s.maxLevel != s.minLevel {
+					levels += "-" + strconv.Itoa(s.maxLevel)
+				}
+				fmt.Printf("    %2d) %-40s Lv%-6s %d%%\n", areaNumbers[area], configure.localize(c, "location-area", area), levels, s.chance())
+			}
+		}
+	}
+
+	choice := prompt(configure, configure.tr("explore which area? (number, or enter to skip) > "))
+	n, err := strconv.Atoi(choice)
+	if err != nil || n < 1 || n > len(areaList) {
+		return nil
+	}
+	if v, ok := flags["version"]; ok {
+		return commandExplore(configure, c, areaList[n-1]+" --version="+v)
+	}
+	return commandExplore(configure, c, areaList[n-1])
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSummarizeEncounters(t *testing.T) {
+	raw := `[
+		{"location_area":{"name":"kanto-route-1-area"},"version_details":[{"version":{"name":"red"},"encounter_details":[
+			{"min_level":2,"max_level":4,"chance":20,"method":{"name":"walk"},"condition_values":[]},
+			{"min_level":3,"max_level":5,"chance":10,"method":{"name":"walk"},"condition_values":[]}]}]},
+		{"location_area":{"name":"johto-route-29-area"},"version_details":[{"version":{"name":"gold"},"encounter_details":[
+			{"min_level":2,"max_level":2,"chance":40,"method":{"name":"walk"},"condition_values":[{"name":"time-morning"}]},
+			{"min_level":3,"max_level":3,"chance":30,"method":{"name":"walk"},"condition_values":[{"name":"time-morning"}]},
+			{"min_level":2,"max_level":2,"chance":40,"method":{"name":"walk"},"condition_values":[{"name":"time-day"}]},
+			{"min_level":3,"max_level":3,"chance":30,"method":{"name":"walk"},"condition_values":[{"name":"time-day"}]},
+			{"min_level":2,"max_level":2,"chance":20,"method":{"name":"walk"},"condition_values":[{"name":"time-night"}]}]}]},
+		{"location_area":{"name":"sea-route-19-area"},"version_details":[{"version":{"name":"red"},"encounter_details":[
+			{"min_level":15,"max_level":20,"chance":60,"method":{"name":"surf"},"condition_values":[]},
+			{"min_level":20,"max_level":25,"chance":60,"method":{"name":"surf"},"condition_values":[]}]}]}
+	]`
+	encounters := make([]areaEncounter, 0)
+	if err := json.Unmarshal([]byte(raw), &encounters); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		version, method, area string
+		minLevel, maxLevel    int
+		chance                int
+	}{
+		{"red", "walk", "kanto-route-1-area", 2, 5, 30},
+		// morning, day and night are alternatives, not added up
+		{"gold", "walk", "johto-route-29-area", 2, 3, 70},
+		// inconsistent API data never shows more than 100%
+		{"red", "surf", "sea-route-19-area", 15, 25, 100},
+	}
+	grouped := summarizeEncounters(encounters, versionFilter{})
+	for _, tc := range cases {
+		s := grouped[tc.version][tc.method][tc.area]
+		if s == nil {
+			t.Errorf("%s %s %s missing from %v", tc.version, tc.method, tc.area, grouped)
+			continue
+		}
+		if s.minLevel != tc.minLevel || s.maxLevel != tc.maxLevel || s.chance() != tc.chance {
+			t.Errorf("%s %s %s = Lv%d-%d %d%%, want Lv%d-%d %d%%", tc.version, tc.method, tc.area,
+				s.minLevel, s.maxLevel, s.chance(), tc.minLevel, tc.maxLevel, tc.chance)
+		}
+	}
+
+	if grouped := summarizeEncounters(encounters, versionFilter{Version: "gold"}); len(grouped) != 1 || grouped["gold"] == nil {
+		t.Errorf("gold only: %v", grouped)
+	}
+}